@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/piglig/go-qr/internal/reedsolomon"
+	"github.com/piglig/go-qr/qrspec"
 )
 
 // builder is the mutable scaffold used to lay out a QR matrix. It owns the
@@ -15,24 +16,39 @@ import (
 // the returned value is immutable and carries no build-time scratch.
 type builder struct {
 	version              int
-	size                 int
+	size                 int // width in modules; the side length of square symbols
+	height               int // height in modules; equals size except for rMQR
 	errorCorrectionLevel Ecc
 	modules              [][]bool // dark/light state of every module
 	isFunction           [][]bool // true where a module belongs to a function pattern
+	symbol               SymbolType
+
+	// layout, when non-nil, records what every drawn module is; kind is the
+	// classification setFunctionModule records. Only buildModuleLayout
+	// switches recording on.
+	layout [][]ModuleInfo
+	kind   ModuleKind
 }
 
 // newBuilder allocates a blank builder for the given version and ECC level.
 func newBuilder(version int, ecl Ecc) *builder {
 	size := version*4 + 17
-	b := &builder{version: version, size: size, errorCorrectionLevel: ecl}
+	return newSizedBuilder(version, size, size, ecl)
+}
+
+// newSizedBuilder allocates a blank width×height builder. Symbologies whose
+// dimensions are not derived from version*4+17 (Micro QR, rMQR) call it
+// directly.
+func newSizedBuilder(version, width, height int, ecl Ecc) *builder {
+	b := &builder{version: version, size: width, height: height, errorCorrectionLevel: ecl}
 	// One backing allocation per grid; rows alias into it.
-	b.modules = make([][]bool, size)
-	b.isFunction = make([][]bool, size)
-	modBacking := make([]bool, size*size)
-	fnBacking := make([]bool, size*size)
-	for i := 0; i < size; i++ {
-		b.modules[i] = modBacking[i*size : (i+1)*size]
-		b.isFunction[i] = fnBacking[i*size : (i+1)*size]
+	b.modules = make([][]bool, height)
+	b.isFunction = make([][]bool, height)
+	modBacking := make([]bool, width*height)
+	fnBacking := make([]bool, width*height)
+	for i := 0; i < height; i++ {
+		b.modules[i] = modBacking[i*width : (i+1)*width]
+		b.isFunction[i] = fnBacking[i*width : (i+1)*width]
 	}
 	return b
 }
@@ -46,6 +62,7 @@ func (q *builder) toQrCode(mask int) *QrCode {
 		errorCorrectionLevel: q.errorCorrectionLevel,
 		mask:                 mask,
 		modules:              q.modules,
+		symbol:               q.symbol,
 	}
 }
 
@@ -80,6 +97,17 @@ func (q *builder) chooseBestMask() int {
 func (q *builder) setFunctionModule(x, y int, isDark bool) {
 	q.modules[y][x] = isDark
 	q.isFunction[y][x] = true
+	if q.layout != nil {
+		q.layout[y][x] = ModuleInfo{Kind: q.kind, Codeword: -1, Bit: -1}
+	}
+}
+
+// setCodewordModule paints bit i of the codeword sequence at (x, y).
+func (q *builder) setCodewordModule(x, y int, data []byte, i int) {
+	q.modules[y][x] = getBit(int(data[i>>3]), 7-(i&7))
+	if q.layout != nil {
+		q.layout[y][x] = ModuleInfo{Kind: ModuleData, Codeword: i >> 3, Bit: 7 - (i & 7)}
+	}
 }
 
 // addEccAndInterLeave appends Reed-Solomon ECC bytes to the raw data and
@@ -91,40 +119,44 @@ func (q *builder) addEccAndInterLeave(data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("%w: data length %d != expected %d", ErrInvalidArgument, len(data), numDataCodewords)
 	}
 
-	numBlocks := numErrorCorrectionBlocks[q.errorCorrectionLevel][q.version]
-	blockEccLen := eccCodeWordsPerBlock[q.errorCorrectionLevel][q.version]
-	rawCodewords := getNumRawDataModules(q.version) / 8
+	blocks := qrspec.BlockStructure(q.version, qrspec.Level(q.errorCorrectionLevel))
+	return interleaveBlocks(data, qrspec.TotalCodewords(q.version), blocks.NumBlocks, blocks.ECCodewordsPerBlock)
+}
 
-	numShortBlocks := int(numBlocks) - rawCodewords%int(numBlocks)
-	shortBlockLen := rawCodewords / int(numBlocks)
+// interleaveBlocks splits data into numBlocks Reed-Solomon blocks sharing
+// rawCodewords (short blocks first, long blocks carry one more data codeword),
+// appends blockEccLen ECC bytes to each and interleaves the result.
+func interleaveBlocks(data []byte, rawCodewords, numBlocks, blockEccLen int) ([]byte, error) {
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
 
 	blocks := make([][]byte, numBlocks)
-	rsDiv, err := reedsolomon.Divisor(int(blockEccLen))
+	rsDiv, err := reedsolomon.Divisor(blockEccLen)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
 	}
-	for i, k := 0, 0; i < int(numBlocks); i++ {
+	for i, k := 0, 0; i < numBlocks; i++ {
 		index := 1
 		if i < numShortBlocks {
 			index = 0
 		}
 
-		dat := make([]byte, shortBlockLen-int(blockEccLen)+index)
-		copy(dat, data[k:k+shortBlockLen-int(blockEccLen)+index])
+		dat := make([]byte, shortBlockLen-blockEccLen+index)
+		copy(dat, data[k:k+shortBlockLen-blockEccLen+index])
 		k += len(dat)
 
 		block := make([]byte, shortBlockLen+1)
 		copy(block, dat)
 
 		ecc := reedsolomon.Remainder(dat, rsDiv)
-		copy(block[len(block)-int(blockEccLen):], ecc)
+		copy(block[len(block)-blockEccLen:], ecc)
 		blocks[i] = block
 	}
 
 	res := make([]byte, rawCodewords)
 	for i, k := 0, 0; i < len(blocks[0]); i++ {
 		for j := 0; j < len(blocks); j++ {
-			if i != shortBlockLen-int(blockEccLen) || j >= numShortBlocks {
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
 				res[k] = blocks[j][i]
 				k++
 			}
@@ -136,7 +168,7 @@ func (q *builder) addEccAndInterLeave(data []byte) ([]byte, error) {
 // drawCodewords fills the non-function modules with the given codeword bytes
 // following the QR Code zig-zag traversal.
 func (q *builder) drawCodewords(data []byte) error {
-	numRawDataModules := getNumRawDataModules(q.version) / 8
+	numRawDataModules := qrspec.TotalCodewords(q.version)
 	if len(data) != numRawDataModules {
 		return fmt.Errorf("%w: codeword length mismatch", ErrInvalidArgument)
 	}
@@ -155,7 +187,7 @@ func (q *builder) drawCodewords(data []byte) error {
 					y = q.size - 1 - vert
 				}
 				if !q.isFunction[y][x] && i < len(data)*8 {
-					q.modules[y][x] = getBit(int(data[i>>3]), 7-(i&7))
+					q.setCodewordModule(x, y, data, i)
 					i++
 				}
 			}
@@ -166,6 +198,7 @@ func (q *builder) drawCodewords(data []byte) error {
 
 // drawAlignmentPattern draws an alignment pattern centered at (x, y).
 func (q *builder) drawAlignmentPattern(x, y int) {
+	q.kind = ModuleAlignment
 	for dy := -2; dy <= 2; dy++ {
 		for dx := -2; dx <= 2; dx++ {
 			q.setFunctionModule(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
@@ -179,7 +212,11 @@ func (q *builder) drawFinderPattern(x, y int) {
 		for dx := -4; dx <= 4; dx++ {
 			dist := max(abs(dx), abs(dy))
 			xx, yy := x+dx, y+dy
-			if 0 <= xx && xx < q.size && 0 <= yy && yy < q.size {
+			q.kind = ModuleFinder
+			if dist == 4 {
+				q.kind = ModuleSeparator
+			}
+			if 0 <= xx && xx < q.size && 0 <= yy && yy < q.height {
 				q.setFunctionModule(xx, yy, dist != 2 && dist != 4)
 			}
 		}
@@ -192,11 +229,8 @@ func (q *builder) drawVersion() {
 		return
 	}
 
-	rem := q.version
-	for i := 0; i < 12; i++ {
-		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
-	}
-	bits := q.version<<12 | rem
+	bits := qrspec.VersionInfoBits(q.version)
+	q.kind = ModuleVersion
 
 	for i := 0; i < 18; i++ {
 		bit := getBit(bits, i)
@@ -211,6 +245,7 @@ func (q *builder) drawVersion() {
 // alignment patterns, format/version info placeholders.
 func (q *builder) drawFunctionPatterns() {
 	// Timing patterns
+	q.kind = ModuleTiming
 	for i := 0; i < q.size; i++ {
 		q.setFunctionModule(6, i, i%2 == 0)
 		q.setFunctionModule(i, 6, i%2 == 0)
@@ -222,7 +257,7 @@ func (q *builder) drawFunctionPatterns() {
 	q.drawFinderPattern(3, q.size-4)
 
 	// Alignment patterns
-	alignPatPos := q.getAlignmentPatternPositions()
+	alignPatPos := qrspec.AlignmentPatternPositions(q.version)
 	numAlign := len(alignPatPos)
 	for i := 0; i < numAlign; i++ {
 		for j := 0; j < numAlign; j++ {
@@ -236,40 +271,15 @@ func (q *builder) drawFunctionPatterns() {
 	q.drawVersion()
 }
 
-// getAlignmentPatternPositions returns the alignment pattern center coordinates
-// for the QR Code version. For version 1 the result is empty.
-func (q *builder) getAlignmentPatternPositions() []int {
-	if q.version == 1 {
-		return []int{}
-	}
-	numAlign := q.version/7 + 2
-	step := 0
-	if q.version == 32 {
-		step = 26
-	} else {
-		step = (q.version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
-	}
-
-	res := make([]int, numAlign)
-	res[0] = 6
-	for i, pos := len(res)-1, q.size-7; i >= 1; {
-		res[i] = pos
-		i--
-		pos -= step
-	}
-
-	return res
-}
-
 // drawFormatBits encodes the ECC level and mask number into the format bits.
 func (q *builder) drawFormatBits(msk int) {
-	data := q.errorCorrectionLevel.FormatBits()<<3 | msk
-	rem := data
-	for i := 0; i < 10; i++ {
-		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
-	}
+	q.drawFormatInfo(qrspec.FormatInfoBits(qrspec.Level(q.errorCorrectionLevel), msk))
+}
 
-	bits := (data<<10 | rem) ^ 0x5412
+// drawFormatInfo draws the 15-bit format information word bits, already
+// BCH-encoded and XOR-masked, in both copies, plus the dark module.
+func (q *builder) drawFormatInfo(bits int) {
+	q.kind = ModuleFormat
 
 	for i := 0; i <= 5; i++ {
 		q.setFunctionModule(8, i, getBit(bits, i))
@@ -289,5 +299,6 @@ func (q *builder) drawFormatBits(msk int) {
 	for i := 8; i < 15; i++ {
 		q.setFunctionModule(8, q.size-15+i, getBit(bits, i))
 	}
+	q.kind = ModuleDarkModule
 	q.setFunctionModule(8, q.size-8, true)
 }
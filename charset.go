@@ -0,0 +1,330 @@
+package go_qr
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Charset is a character encoding that byte-mode segments can be transcoded
+// into, announced to readers with its AIM ECI designator.
+type Charset int
+
+const (
+	CharsetUTF8        Charset = iota // UTF-8, ECI 26
+	CharsetISO8859_1                  // Latin-1, ECI 3
+	CharsetISO8859_2                  // Latin-2, ECI 4
+	CharsetISO8859_3                  // Latin-3, ECI 5
+	CharsetISO8859_4                  // Latin-4, ECI 6
+	CharsetISO8859_5                  // Cyrillic, ECI 7
+	CharsetISO8859_6                  // Arabic, ECI 8
+	CharsetISO8859_7                  // Greek, ECI 9
+	CharsetISO8859_8                  // Hebrew, ECI 10
+	CharsetISO8859_9                  // Latin-5, ECI 11
+	CharsetISO8859_10                 // Latin-6, ECI 12
+	CharsetISO8859_11                 // Thai, ECI 13
+	CharsetISO8859_13                 // Latin-7, ECI 15
+	CharsetISO8859_14                 // Latin-8, ECI 16
+	CharsetISO8859_15                 // Latin-9, ECI 17
+	CharsetISO8859_16                 // Latin-10, ECI 18
+	CharsetShiftJIS                   // Shift_JIS (JIS X 0208), ECI 20
+	CharsetWindows1250                // Central European, ECI 21
+	CharsetWindows1251                // Cyrillic, ECI 22
+	CharsetWindows1252                // Western European, ECI 23
+	CharsetWindows1256                // Arabic, ECI 24
+)
+
+// charsetInfo is the name and ECI assignment of each Charset. The other
+// Windows-125x code pages have no ECI assignment and so are not offered.
+var charsetInfo = [...]struct {
+	name string
+	eci  int
+}{
+	CharsetUTF8:        {"UTF-8", 26},
+	CharsetISO8859_1:   {"ISO-8859-1", 3},
+	CharsetISO8859_2:   {"ISO-8859-2", 4},
+	CharsetISO8859_3:   {"ISO-8859-3", 5},
+	CharsetISO8859_4:   {"ISO-8859-4", 6},
+	CharsetISO8859_5:   {"ISO-8859-5", 7},
+	CharsetISO8859_6:   {"ISO-8859-6", 8},
+	CharsetISO8859_7:   {"ISO-8859-7", 9},
+	CharsetISO8859_8:   {"ISO-8859-8", 10},
+	CharsetISO8859_9:   {"ISO-8859-9", 11},
+	CharsetISO8859_10:  {"ISO-8859-10", 12},
+	CharsetISO8859_11:  {"ISO-8859-11", 13},
+	CharsetISO8859_13:  {"ISO-8859-13", 15},
+	CharsetISO8859_14:  {"ISO-8859-14", 16},
+	CharsetISO8859_15:  {"ISO-8859-15", 17},
+	CharsetISO8859_16:  {"ISO-8859-16", 18},
+	CharsetShiftJIS:    {"Shift_JIS", 20},
+	CharsetWindows1250: {"windows-1250", 21},
+	CharsetWindows1251: {"windows-1251", 22},
+	CharsetWindows1252: {"windows-1252", 23},
+	CharsetWindows1256: {"windows-1256", 24},
+}
+
+// String returns the IANA name of the charset.
+func (c Charset) String() string {
+	if !c.valid() {
+		return fmt.Sprintf("Charset(%d)", int(c))
+	}
+	return charsetInfo[c].name
+}
+
+// ECI returns the charset's ECI assignment number, for use with MakeEci.
+func (c Charset) ECI() int {
+	if !c.valid() {
+		return -1
+	}
+	return charsetInfo[c].eci
+}
+
+func (c Charset) valid() bool {
+	return 0 <= c && int(c) < len(charsetInfo)
+}
+
+// charsetForECI returns the charset with ECI assignment eci. ECI 1 is the
+// legacy designator for ISO-8859-1.
+func charsetForECI(eci int) (Charset, bool) {
+	if eci == 1 {
+		return CharsetISO8859_1, true
+	}
+	for c := range charsetInfo {
+		if charsetInfo[c].eci == eci {
+			return Charset(c), true
+		}
+	}
+	return 0, false
+}
+
+var (
+	singleByteEncoders     map[Charset]map[rune]byte
+	singleByteEncodersOnce sync.Once
+)
+
+// singleByteEncoder returns the rune-to-byte table of a single-byte charset,
+// inverted once from singleByteCharsetHighHalves.
+func singleByteEncoder(c Charset) map[rune]byte {
+	singleByteEncodersOnce.Do(func() {
+		singleByteEncoders = make(map[Charset]map[rune]byte, len(singleByteCharsetHighHalves))
+		for cs, high := range singleByteCharsetHighHalves {
+			m := make(map[rune]byte, 128)
+			for i, r := range []rune(high) {
+				if r != utf8.RuneError {
+					m[r] = byte(0x80 + i)
+				}
+			}
+			singleByteEncoders[cs] = m
+		}
+	})
+	return singleByteEncoders[c]
+}
+
+var (
+	qrKanjiToUnicodeTable []rune
+	qrKanjiToUnicodeOnce  sync.Once
+)
+
+// qrKanjiToUnicode returns the code point of every 13-bit QR Kanji value,
+// utf8.RuneError where the value is unassigned.
+func qrKanjiToUnicode() []rune {
+	qrKanjiToUnicodeOnce.Do(func() {
+		packed, _ := base64.StdEncoding.DecodeString(packedQRKanjiToUnicode)
+		qrKanjiToUnicodeTable = make([]rune, len(packed)/2)
+		for i := range qrKanjiToUnicodeTable {
+			c := rune(packed[2*i])<<8 | rune(packed[2*i+1])
+			if c == 0xFFFF {
+				c = utf8.RuneError
+			}
+			qrKanjiToUnicodeTable[i] = c
+		}
+	})
+	return qrKanjiToUnicodeTable
+}
+
+// appendRune appends the encoding of r in c to dst. ok is false if c cannot
+// represent r.
+func (c Charset) appendRune(dst []byte, r rune) ([]byte, bool) {
+	switch {
+	case c == CharsetUTF8:
+		if !utf8.ValidRune(r) {
+			return dst, false
+		}
+		return utf8.AppendRune(dst, r), true
+	case r < 0x80:
+		return append(dst, byte(r)), true
+	case c == CharsetISO8859_1:
+		return append(dst, byte(r)), r < 0x100
+	case c == CharsetShiftJIS:
+		if 0xFF61 <= r && r <= 0xFF9F { // half-width katakana
+			return append(dst, byte(r-0xFF61+0xA1)), true
+		}
+		if !isKanji(int(r)) {
+			return dst, false
+		}
+		// Undo the Kanji mode compaction (see MakeKanji) to recover the
+		// Shift_JIS code.
+		val := unicdeToQRKanji[r]
+		code := val/0xC0<<8 | val%0xC0
+		if code < 0x1F00 {
+			code += 0x8140
+		} else {
+			code += 0xC140
+		}
+		return append(dst, byte(code>>8), byte(code)), true
+	}
+	b, ok := singleByteEncoder(c)[r]
+	if !ok {
+		return dst, false
+	}
+	return append(dst, b), true
+}
+
+// Encode transcodes text into the charset. It returns ErrUnencodableChar for
+// the first character the charset cannot represent.
+func (c Charset) Encode(text string) ([]byte, error) {
+	if !c.valid() {
+		return nil, fmt.Errorf("%w: unknown charset %d", ErrInvalidArgument, int(c))
+	}
+	res := make([]byte, 0, len(text))
+	for i, r := range text {
+		var ok bool
+		if res, ok = c.appendRune(res, r); !ok {
+			return nil, &UnencodableCharError{Rune: r, Offset: i, Target: c.String()}
+		}
+	}
+	return res, nil
+}
+
+// decode converts bytes in the charset back to a UTF-8 string, substituting
+// U+FFFD for invalid sequences.
+func (c Charset) decode(b []byte) string {
+	if c == CharsetUTF8 {
+		return string(b)
+	}
+	high := []rune(singleByteCharsetHighHalves[c])
+	sb := strings.Builder{}
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] < 0x80:
+			sb.WriteByte(b[i])
+		case c == CharsetISO8859_1:
+			sb.WriteRune(rune(b[i]))
+		case c == CharsetShiftJIS:
+			sb.WriteRune(decodeShiftJIS(b, &i))
+		default:
+			sb.WriteRune(high[b[i]-0x80])
+		}
+	}
+	return sb.String()
+}
+
+// decodeShiftJIS decodes the non-ASCII Shift_JIS character at b[*i], advancing
+// *i past a trail byte.
+func decodeShiftJIS(b []byte, i *int) rune {
+	lead := b[*i]
+	if 0xA1 <= lead && lead <= 0xDF {
+		return rune(lead) - 0xA1 + 0xFF61
+	}
+	if *i+1 >= len(b) || !(0x81 <= lead && lead <= 0x9F || 0xE0 <= lead && lead <= 0xEB) {
+		return utf8.RuneError
+	}
+	*i++
+	code := int(lead)<<8 | int(b[*i])
+	if code < 0xE040 {
+		code -= 0x8140
+	} else {
+		code -= 0xC140
+	}
+	val := code>>8*0xC0 + code&0xFF
+	table := qrKanjiToUnicode()
+	if code < 0 || code&0xFF >= 0xC0 || val >= len(table) {
+		return utf8.RuneError
+	}
+	return table[val]
+}
+
+// MakeSegmentsCharset is MakeSegmentsOptimally with byte-mode runs transcoded
+// into cs. Unless the text is pure ASCII, the result starts with an ECI
+// segment announcing cs, and its header bits count against the capacity.
+func MakeSegmentsCharset(text string, cs Charset, ecl Ecc, minVersion, maxVersion int) ([]*QrSegment, error) {
+	if !isValidVersion(minVersion, maxVersion) {
+		return nil, fmt.Errorf("%w: minVersion=%d maxVersion=%d", ErrInvalidVersion, minVersion, maxVersion)
+	}
+	if !cs.valid() {
+		return nil, fmt.Errorf("%w: unknown charset %d", ErrInvalidArgument, int(cs))
+	}
+
+	codePoints, err := toCodePoints(text)
+	if err != nil {
+		return nil, err
+	}
+	var eci *QrSegment
+	if !isASCII(text) {
+		if eci, err = MakeEci(cs.ECI()); err != nil {
+			return nil, err
+		}
+	}
+	return makeSegmentsOptimallyCharset(codePoints, ecl, minVersion, maxVersion, cs, eci, false)
+}
+
+// MakeSegmentsAutoCharset segments text in each candidate charset (UTF-8 when
+// none are given) and returns the result that fits the smallest version,
+// breaking ties by the fewest bits. ECI headers are included in the
+// comparison, so a charset only wins when its shorter byte runs pay for the
+// ECI segment. Candidates that cannot represent the text are skipped.
+func MakeSegmentsAutoCharset(text string, ecl Ecc, minVersion, maxVersion int, candidates ...Charset) ([]*QrSegment, Charset, error) {
+	if len(candidates) == 0 {
+		candidates = []Charset{CharsetUTF8}
+	}
+
+	var best []*QrSegment
+	var bestCs Charset
+	bestVersion, bestBits := 0, 0
+	var firstErr error
+	for _, cs := range candidates {
+		segs, err := MakeSegmentsCharset(text, cs, ecl, minVersion, maxVersion)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		version, bits, err := fitVersion(segs, ecl, minVersion, maxVersion)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if best == nil || version < bestVersion || version == bestVersion && bits < bestBits {
+			best, bestCs, bestVersion, bestBits = segs, cs, version, bits
+		}
+	}
+	if best == nil {
+		return nil, 0, firstErr
+	}
+	return best, bestCs, nil
+}
+
+// EncodeTextCharset encodes text at the given error correction level (boosted
+// when the data still fits), choosing among the candidate charsets with
+// MakeSegmentsAutoCharset.
+func EncodeTextCharset(text string, ecl Ecc, candidates ...Charset) (*QrCode, error) {
+	segs, _, err := MakeSegmentsAutoCharset(text, ecl, MinVersion, MaxVersion, candidates...)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeStandardSegments(segs, ecl)
+}
+
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package go_qr
+
+// singleByteCharsetHighHalves holds the code points of bytes 0x80-0xFF in each
+// single-byte charset, generated from the Unicode mapping files. Bytes below
+// 0x80 are ASCII in all of them. U+FFFD marks bytes a charset leaves undefined.
+// ISO-8859-1 maps every byte to the code point of the same value and needs no
+// table.
+var singleByteCharsetHighHalves = map[Charset]string{
+	CharsetISO8859_2: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0Ą˘Ł¤ĽŚ§¨ŠŞŤŹ\u00ADŽŻ" +
+		"°ą˛ł´ľśˇ¸šşťź˝žż" +
+		"ŔÁÂĂÄĹĆÇČÉĘËĚÍÎĎ" +
+		"ĐŃŇÓÔŐÖ×ŘŮÚŰÜÝŢß" +
+		"ŕáâăäĺćçčéęëěíîď" +
+		"đńňóôőö÷řůúűüýţ˙",
+	CharsetISO8859_3: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0Ħ˘£¤�Ĥ§¨İŞĞĴ\u00AD�Ż" +
+		"°ħ²³´µĥ·¸ışğĵ½�ż" +
+		"ÀÁÂ�ÄĊĈÇÈÉÊËÌÍÎÏ" +
+		"�ÑÒÓÔĠÖ×ĜÙÚÛÜŬŜß" +
+		"àáâ�äċĉçèéêëìíîï" +
+		"�ñòóôġö÷ĝùúûüŭŝ˙",
+	CharsetISO8859_4: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0ĄĸŖ¤ĨĻ§¨ŠĒĢŦ\u00ADŽ¯" +
+		"°ą˛ŗ´ĩļˇ¸šēģŧŊžŋ" +
+		"ĀÁÂÃÄÅÆĮČÉĘËĖÍÎĪ" +
+		"ĐŅŌĶÔÕÖ×ØŲÚÛÜŨŪß" +
+		"āáâãäåæįčéęëėíîī" +
+		"đņōķôõö÷øųúûüũū˙",
+	CharsetISO8859_5: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0ЁЂЃЄЅІЇЈЉЊЋЌ\u00ADЎЏ" +
+		"АБВГДЕЖЗИЙКЛМНОП" +
+		"РСТУФХЦЧШЩЪЫЬЭЮЯ" +
+		"абвгдежзийклмноп" +
+		"рстуфхцчшщъыьэюя" +
+		"№ёђѓєѕіїјљњћќ§ўџ",
+	CharsetISO8859_6: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0���¤�������،\u00AD��" +
+		"�����������؛���؟" +
+		"�ءآأؤإئابةتثجحخد" +
+		"ذرزسشصضطظعغ�����" +
+		"ـفقكلمنهوىي\u064B\u064C\u064D\u064E\u064F" +
+		"\u0650\u0651\u0652�������������",
+	CharsetISO8859_7: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0‘’£€₯¦§¨©ͺ«¬\u00AD�―" +
+		"°±²³΄΅Ά·ΈΉΊ»Ό½ΎΏ" +
+		"ΐΑΒΓΔΕΖΗΘΙΚΛΜΝΞΟ" +
+		"ΠΡ�ΣΤΥΦΧΨΩΪΫάέήί" +
+		"ΰαβγδεζηθικλμνξο" +
+		"πρςστυφχψωϊϋόύώ�",
+	CharsetISO8859_8: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0�¢£¤¥¦§¨©×«¬\u00AD®¯" +
+		"°±²³´µ¶·¸¹÷»¼½¾�" +
+		"����������������" +
+		"���������������‗" +
+		"אבגדהוזחטיךכלםמן" +
+		"נסעףפץצקרשת��\u200E\u200F�",
+	CharsetISO8859_9: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0¡¢£¤¥¦§¨©ª«¬\u00AD®¯" +
+		"°±²³´µ¶·¸¹º»¼½¾¿" +
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏ" +
+		"ĞÑÒÓÔÕÖ×ØÙÚÛÜİŞß" +
+		"àáâãäåæçèéêëìíîï" +
+		"ğñòóôõö÷øùúûüışÿ",
+	CharsetISO8859_10: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0ĄĒĢĪĨĶ§ĻĐŠŦŽ\u00ADŪŊ" +
+		"°ąēģīĩķ·ļđšŧž―ūŋ" +
+		"ĀÁÂÃÄÅÆĮČÉĘËĖÍÎÏ" +
+		"ÐŅŌÓÔÕÖŨØŲÚÛÜÝÞß" +
+		"āáâãäåæįčéęëėíîï" +
+		"ðņōóôõöũøųúûüýþĸ",
+	CharsetISO8859_11: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0กขฃคฅฆงจฉชซฌญฎฏ" +
+		"ฐฑฒณดตถทธนบปผฝพฟ" +
+		"ภมยรฤลฦวศษสหฬอฮฯ" +
+		"ะ\u0E31าำ\u0E34\u0E35\u0E36\u0E37\u0E38\u0E39\u0E3A����฿" +
+		"เแโใไๅๆ\u0E47\u0E48\u0E49\u0E4A\u0E4B\u0E4C\u0E4D\u0E4E๏" +
+		"๐๑๒๓๔๕๖๗๘๙๚๛����",
+	CharsetISO8859_13: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0”¢£¤„¦§Ø©Ŗ«¬\u00AD®Æ" +
+		"°±²³“µ¶·ø¹ŗ»¼½¾æ" +
+		"ĄĮĀĆÄÅĘĒČÉŹĖĢĶĪĻ" +
+		"ŠŃŅÓŌÕÖ×ŲŁŚŪÜŻŽß" +
+		"ąįāćäåęēčéźėģķīļ" +
+		"šńņóōõö÷ųłśūüżž’",
+	CharsetISO8859_14: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0Ḃḃ£ĊċḊ§Ẁ©ẂḋỲ\u00AD®Ÿ" +
+		"ḞḟĠġṀṁ¶ṖẁṗẃṠỳẄẅṡ" +
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏ" +
+		"ŴÑÒÓÔÕÖṪØÙÚÛÜÝŶß" +
+		"àáâãäåæçèéêëìíîï" +
+		"ŵñòóôõöṫøùúûüýŷÿ",
+	CharsetISO8859_15: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0¡¢£€¥Š§š©ª«¬\u00AD®¯" +
+		"°±²³Žµ¶·ž¹º»ŒœŸ¿" +
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏ" +
+		"ÐÑÒÓÔÕÖ×ØÙÚÛÜÝÞß" +
+		"àáâãäåæçèéêëìíîï" +
+		"ðñòóôõö÷øùúûüýþÿ",
+	CharsetISO8859_16: "" +
+		"\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008A\u008B\u008C\u008D\u008E\u008F" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009A\u009B\u009C\u009D\u009E\u009F" +
+		"\u00A0ĄąŁ€„Š§š©Ș«Ź\u00ADźŻ" +
+		"°±ČłŽ”¶·žčș»ŒœŸż" +
+		"ÀÁÂĂÄĆÆÇÈÉÊËÌÍÎÏ" +
+		"ĐŃÒÓÔŐÖŚŰÙÚÛÜĘȚß" +
+		"àáâăäćæçèéêëìíîï" +
+		"đńòóôőöśűùúûüęțÿ",
+	CharsetWindows1250: "" +
+		"€�‚�„…†‡�‰Š‹ŚŤŽŹ" +
+		"�‘’“”•–—�™š›śťžź" +
+		"\u00A0ˇ˘Ł¤Ą¦§¨©Ş«¬\u00AD®Ż" +
+		"°±˛ł´µ¶·¸ąş»Ľ˝ľż" +
+		"ŔÁÂĂÄĹĆÇČÉĘËĚÍÎĎ" +
+		"ĐŃŇÓÔŐÖ×ŘŮÚŰÜÝŢß" +
+		"ŕáâăäĺćçčéęëěíîď" +
+		"đńňóôőö÷řůúűüýţ˙",
+	CharsetWindows1251: "" +
+		"ЂЃ‚ѓ„…†‡€‰Љ‹ЊЌЋЏ" +
+		"ђ‘’“”•–—�™љ›њќћџ" +
+		"\u00A0ЎўЈ¤Ґ¦§Ё©Є«¬\u00AD®Ї" +
+		"°±Ііґµ¶·ё№є»јЅѕї" +
+		"АБВГДЕЖЗИЙКЛМНОП" +
+		"РСТУФХЦЧШЩЪЫЬЭЮЯ" +
+		"абвгдежзийклмноп" +
+		"рстуфхцчшщъыьэюя",
+	CharsetWindows1252: "" +
+		"€�‚ƒ„…†‡ˆ‰Š‹Œ�Ž�" +
+		"�‘’“”•–—˜™š›œ�žŸ" +
+		"\u00A0¡¢£¤¥¦§¨©ª«¬\u00AD®¯" +
+		"°±²³´µ¶·¸¹º»¼½¾¿" +
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏ" +
+		"ÐÑÒÓÔÕÖ×ØÙÚÛÜÝÞß" +
+		"àáâãäåæçèéêëìíîï" +
+		"ðñòóôõö÷øùúûüýþÿ",
+	CharsetWindows1256: "" +
+		"€پ‚ƒ„…†‡ˆ‰ٹ‹Œچژڈ" +
+		"گ‘’“”•–—ک™ڑ›œ\u200C\u200Dں" +
+		"\u00A0،¢£¤¥¦§¨©ھ«¬\u00AD®¯" +
+		"°±²³´µ¶·¸¹؛»¼½¾؟" +
+		"ہءآأؤإئابةتثجحخد" +
+		"ذرزسشصض×طظعغـفقك" +
+		"àلâمنهوçèéêëىيîï" +
+		"\u064B\u064C\u064D\u064Eô\u064F\u0650÷\u0651ù\u0652ûü\u200E\u200Fے",
+}
@@ -0,0 +1,148 @@
+package go_qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharsetEncode(t *testing.T) {
+	tests := []struct {
+		cs   Charset
+		text string
+		want []byte
+	}{
+		{CharsetISO8859_1, "Grüße", []byte{'G', 'r', 0xFC, 0xDF, 'e'}},
+		{CharsetISO8859_15, "€5", []byte{0xA4, '5'}},
+		{CharsetWindows1252, "€5", []byte{0x80, '5'}},
+		{CharsetWindows1251, "Привет", []byte{0xCF, 0xF0, 0xE8, 0xE2, 0xE5, 0xF2}},
+		{CharsetISO8859_7, "Ωμέγα", []byte{0xD9, 0xEC, 0xDD, 0xE3, 0xE1}},
+		{CharsetShiftJIS, "点ｱa", []byte{0x93, 0x5F, 0xB1, 'a'}},
+		{CharsetShiftJIS, "茗", []byte{0xE4, 0xAA}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cs.String(), func(t *testing.T) {
+			got, err := tt.cs.Encode(tt.text)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.text, tt.cs.decode(got))
+		})
+	}
+
+	_, err := CharsetISO8859_1.Encode("a€")
+	assert.True(t, errors.Is(err, ErrUnencodableChar))
+	_, err = CharsetShiftJIS.Encode("é")
+	assert.True(t, errors.Is(err, ErrUnencodableChar))
+}
+
+func TestCharsetECI(t *testing.T) {
+	assert.Equal(t, 26, CharsetUTF8.ECI())
+	assert.Equal(t, 20, CharsetShiftJIS.ECI())
+	cs, ok := charsetForECI(22)
+	assert.True(t, ok)
+	assert.Equal(t, CharsetWindows1251, cs)
+	cs, ok = charsetForECI(1)
+	assert.True(t, ok)
+	assert.Equal(t, CharsetISO8859_1, cs)
+	_, ok = charsetForECI(14)
+	assert.False(t, ok)
+}
+
+func TestMakeSegmentsCharset(t *testing.T) {
+	// Pure ASCII needs no ECI.
+	segs, err := MakeSegmentsCharset("HELLO 123", CharsetISO8859_1, Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	for _, seg := range segs {
+		assert.False(t, seg.mode.isEci())
+	}
+
+	segs, err = MakeSegmentsCharset("Grüße", CharsetISO8859_1, Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	assert.True(t, segs[0].mode.isEci())
+	assert.Equal(t, 5, segs[1].numChars)
+
+	_, err = MakeSegmentsCharset("日本", CharsetISO8859_1, Low, MinVersion, MaxVersion)
+	assert.True(t, errors.Is(err, ErrUnencodableChar))
+}
+
+// TestMakeSegmentsAutoCharset checks that the choice is made on total bits,
+// ECI header included.
+func TestMakeSegmentsAutoCharset(t *testing.T) {
+	segs, cs, err := MakeSegmentsAutoCharset("café", Low, MinVersion, MaxVersion, CharsetUTF8, CharsetISO8859_1)
+	assert.NoError(t, err)
+	assert.Equal(t, CharsetISO8859_1, cs)
+	// ECI (4+8) + byte header (4+8) + 4 bytes.
+	assert.Equal(t, 12+12+32, getTotalBits(segs, 1))
+
+	_, cs, err = MakeSegmentsAutoCharset("Привет, мир", Low, MinVersion, MaxVersion, CharsetUTF8, CharsetISO8859_1, CharsetWindows1251)
+	assert.NoError(t, err)
+	assert.Equal(t, CharsetWindows1251, cs)
+
+	_, _, err = MakeSegmentsAutoCharset("日本", Low, MinVersion, MaxVersion, CharsetISO8859_1)
+	assert.True(t, errors.Is(err, ErrUnencodableChar))
+}
+
+func TestCharsetEmptyText(t *testing.T) {
+	segs, err := MakeSegmentsCharset("", CharsetISO8859_1, Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	assert.Empty(t, segs)
+
+	segs, cs, err := MakeSegmentsAutoCharset("", Low, MinVersion, MaxVersion, CharsetUTF8, CharsetISO8859_1)
+	assert.NoError(t, err)
+	assert.Empty(t, segs)
+	assert.Equal(t, CharsetUTF8, cs)
+
+	qr, err := EncodeTextCharset("", Low, CharsetISO8859_1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, qr.version)
+}
+
+func TestMakeSegmentsAutoCharsetCountOverflow(t *testing.T) {
+	// 400 UTF-8 bytes overflow the 8-bit byte count of versions 1-9, which
+	// must not count as fitting them; 200 ISO-8859-1 bytes fit version 9.
+	text := strings.Repeat("ß", 200)
+	segs, cs, err := MakeSegmentsAutoCharset(text, Low, MinVersion, MaxVersion, CharsetISO8859_1, CharsetUTF8)
+	assert.NoError(t, err)
+	assert.Equal(t, CharsetISO8859_1, cs)
+	ver, _, err := fitVersion(segs, Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, ver)
+
+	_, cs, err = MakeSegmentsAutoCharset(text, Low, MinVersion, MaxVersion, CharsetUTF8, CharsetISO8859_1)
+	assert.NoError(t, err)
+	assert.Equal(t, CharsetISO8859_1, cs)
+
+	// A candidate that fits no version up to the maximum is skipped.
+	_, cs, err = MakeSegmentsAutoCharset(text, Low, MinVersion, 9, CharsetUTF8, CharsetISO8859_1)
+	assert.NoError(t, err)
+	assert.Equal(t, CharsetISO8859_1, cs)
+}
+
+func TestEncodeTextCharsetRoundTrip(t *testing.T) {
+	tests := []struct {
+		text string
+		cs   Charset
+	}{
+		{"Größe: 42 cm × 17 cm", CharsetISO8859_1},
+		{"Привет, мир! 2024", CharsetWindows1251},
+		{"ｶﾀｶﾅ ABC", CharsetShiftJIS},
+		{"Ελληνικά κείμενα", CharsetISO8859_7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cs.String(), func(t *testing.T) {
+			segs, cs, err := MakeSegmentsAutoCharset(tt.text, Medium, MinVersion, MaxVersion, CharsetUTF8, tt.cs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cs, cs)
+
+			qr, err := EncodeStandardSegments(segs, Medium)
+			assert.NoError(t, err)
+			img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+			assert.NoError(t, err)
+			got, err := Decode(img)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.text, got)
+		})
+	}
+}
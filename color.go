@@ -3,6 +3,7 @@ package go_qr
 import (
 	"fmt"
 	"image/color"
+	"math"
 )
 
 // colorToSVGHex formats a color.Color as an SVG-compatible string.
@@ -22,3 +23,24 @@ func colorIsTransparent(c color.Color) bool {
 	_, _, _, a := c.RGBA()
 	return a == 0
 }
+
+// relativeLuminance returns the WCAG relative luminance of c, ignoring alpha:
+// 0 for black, 1 for white.
+func relativeLuminance(c color.Color) float64 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	linear := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(n.R) + 0.7152*linear(n.G) + 0.0722*linear(n.B)
+}
+
+// contrastRatio returns the WCAG contrast ratio of a and b, from 1 for equal
+// luminance to 21 for black on white.
+func contrastRatio(a, b color.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
@@ -15,6 +15,13 @@ type QrCodeImgConfig struct {
 	svgXMLHeader  bool
 	optimalSVG    bool
 	logo          *logoConfig
+	moduleShape   ModuleShape
+	patternShape  ModuleShape
+	eyes          map[Finder]EyeStyle
+	gradient      *gradient
+	cornerRadius  float64
+	pdfPageSize   [2]float64 // millimetres; zero for scale points per module
+	pdfCMYK       bool
 }
 
 // NewQrCodeImgConfig creates a QR code generation config with the provided scale
@@ -38,7 +45,19 @@ func (q *QrCodeImgConfig) valid() error {
 	if q.border < 0 {
 		return fmt.Errorf("%w: border must be non-negative", ErrInvalidConfig)
 	}
-	return nil
+
+	if !q.moduleShape.valid() || !q.patternShape.valid() {
+		return fmt.Errorf("%w: unknown module shape", ErrInvalidConfig)
+	}
+	if q.gradient != nil {
+		if err := q.gradient.valid(q.light); err != nil {
+			return err
+		}
+	}
+	if err := q.validCornerRadius(); err != nil {
+		return err
+	}
+	return q.validEyes()
 }
 
 // Light returns the light (background) color.
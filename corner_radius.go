@@ -0,0 +1,101 @@
+package go_qr
+
+import "fmt"
+
+// maxCornerRadius is the largest corner radius, in module units: half a
+// module, so the arcs at both ends of a one-module edge meet.
+const maxCornerRadius = 0.5
+
+// WithCornerRadius rounds the outline of connected dark regions: every outer
+// corner becomes an arc of radius radius, in module units (at most 0.5), and
+// every inner corner a concave fillet of the same radius, for a fluid,
+// connected look. PNG and ToImage rasterize the same outline. SVG output
+// traces the outline as WithOptimalSVG does, whether or not that is set.
+//
+// Modules drawn with a WithModuleShape other than ModuleSquare, and styled
+// finder eyes, are not part of the outline.
+func WithCornerRadius(radius float64) Option {
+	return func(q *QrCodeImgConfig) {
+		q.cornerRadius = radius
+	}
+}
+
+// validCornerRadius checks that the corner radius is within [0, 0.5].
+func (q *QrCodeImgConfig) validCornerRadius() error {
+	if !(q.cornerRadius >= 0 && q.cornerRadius <= maxCornerRadius) {
+		return fmt.Errorf("%w: corner radius %g outside [0,%g]", ErrInvalidConfig, q.cornerRadius, maxCornerRadius)
+	}
+	return nil
+}
+
+// writeOutline writes every loop of the graph, with every corner of radius r,
+// in module units, cut back along both edges and joined by a quarter arc: a
+// convex arc at outer corners and a concave one at inner corners. A zero
+// radius leaves the corners square. border is the offset of the symbol in
+// output units.
+func (g *borderGraph) writeOutline(p pathWriter, border, scale int, r float64) {
+	rad := r * float64(scale)
+	for _, corners := range g.loops() {
+		n := len(corners)
+		var lastX, lastY float64 // current point, to skip empty edges
+		for i := 0; i <= n; i++ {
+			prev, cur, next := corners[(i+n-1)%n], corners[i%n], corners[(i+1)%n]
+			inX, inY := sign(cur.x-prev.x), sign(cur.y-prev.y)
+			outX, outY := sign(next.x-cur.x), sign(next.y-cur.y)
+			x, y := cur.imageXY(border, scale)
+			endX, endY := float64(x)+float64(outX)*rad, float64(y)+float64(outY)*rad
+			if i == 0 {
+				p.moveTo(endX, endY)
+				lastX, lastY = endX, endY
+				continue
+			}
+			if startX, startY := float64(x)-float64(inX)*rad, float64(y)-float64(inY)*rad; startX != lastX || startY != lastY {
+				p.lineTo(startX, startY)
+			}
+			if rad > 0 {
+				// A positive cross product is a clockwise turn on screen.
+				p.arcTo(rad, endX, endY, inX*outY-inY*outX > 0)
+			}
+			lastX, lastY = endX, endY
+		}
+		p.closePath()
+	}
+}
+
+// roundedContains reports whether the point (fx, fy), in module units
+// relative to the top-left corner of module (x, y), lies inside the outline
+// of the filled regions with corners of radius r. Only the grid corner of the
+// quadrant holding the point matters: a filled module loses the part outside
+// the arc when both modules beside that corner are empty, and an empty module
+// gains it when those two and the diagonal one are filled. This matches
+// writeOutline, where diagonally touching modules are separate loops.
+func roundedContains(filled func(x, y int) bool, r float64, x, y int, fx, fy float64) bool {
+	in := filled(x, y)
+	dx, dy := -1, -1
+	cx, cy := fx, fy // distance to the quadrant's grid corner along each axis
+	if fx >= 0.5 {
+		dx, cx = 1, 1-fx
+	}
+	if fy >= 0.5 {
+		dy, cy = 1, 1-fy
+	}
+	if cx >= r || cy >= r || sq(r-cx)+sq(r-cy) <= r*r {
+		return in
+	}
+	side, vert := filled(x+dx, y), filled(x, y+dy)
+	if in {
+		return side || vert
+	}
+	return side && vert && filled(x+dx, y+dy)
+}
+
+// sign returns -1, 0 or 1 for the sign of v.
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
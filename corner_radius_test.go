@@ -0,0 +1,105 @@
+package go_qr
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCornerRadiusDecodes(t *testing.T) {
+	text := "https://example.com/liquid"
+	qr, err := EncodeText(text, Medium)
+	assert.NoError(t, err)
+	for _, r := range []float64{0.25, 0.5} {
+		img, err := qr.ToImage(NewQrCodeImgConfig(8, 4, WithCornerRadius(r)))
+		assert.NoError(t, err)
+		decoded, err := Decode(img)
+		assert.NoError(t, err, "radius %g", r)
+		assert.Equal(t, text, decoded, "radius %g", r)
+	}
+}
+
+func TestCornerRadiusPNG(t *testing.T) {
+	qr, err := EncodeText("LIQUID", Low)
+	assert.NoError(t, err)
+	const scale = 10
+	img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0, WithCornerRadius(0.5)))
+	assert.NoError(t, err)
+	black := color.RGBA{A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	// The outer corner of the top-left finder is cut off, its module center
+	// kept.
+	assert.Equal(t, white, img.RGBAAt(0, 0))
+	assert.Equal(t, black, img.RGBAAt(scale/2, scale/2))
+	// The inner corner of the finder ring gets a fillet in the light module
+	// (1, 1), whose center stays light.
+	assert.Equal(t, black, img.RGBAAt(scale, scale))
+	assert.Equal(t, white, img.RGBAAt(scale+scale/2, scale+scale/2))
+	// Straight edges are untouched.
+	assert.Equal(t, black, img.RGBAAt(3*scale, 0))
+}
+
+func TestCornerRadiusSVG(t *testing.T) {
+	qr, err := EncodeText("LIQUID SVG", Low)
+	assert.NoError(t, err)
+
+	// A lone module with radius 0.5 is a circle of four arcs.
+	var sb strings.Builder
+	lone := func(x, y int) bool { return x == 9 && y == 9 }
+	qr.assembleBorderGraphOf(lone).writeOutline(svgPath{&sb}, 4, 10, 0.5)
+	assert.Equal(t, "M99,94A5,5 0 0 1 104,99A5,5 0 0 1 99,104A5,5 0 0 1 94,99A5,5 0 0 1 99,94Z", sb.String())
+
+	optimal, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithCornerRadius(0.3), WithOptimalSVG()))
+	assert.NoError(t, err)
+	s := string(optimal)
+	assert.Contains(t, s, "A3,3 0 0 1 ")
+	assert.Contains(t, s, "A3,3 0 0 0 ")
+	assert.NotContains(t, s, "H")
+	// The top-left finder's outer edge starts 3 units in from its corner.
+	assert.Contains(t, s, "M7,4L71,4A3,3 0 0 1 74,7")
+
+	// The corner radius always traces outlines, and a zero radius changes
+	// nothing.
+	plain, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithCornerRadius(0.3)))
+	assert.NoError(t, err)
+	assert.Equal(t, optimal, plain)
+	zero, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithCornerRadius(0)))
+	assert.NoError(t, err)
+	square, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, square, zero)
+}
+
+func TestRoundedContains(t *testing.T) {
+	// A lone module with radius 0.5 is the inscribed circle.
+	lone := func(x, y int) bool { return x == 0 && y == 0 }
+	for fy := 0.05; fy < 1; fy += 0.1 {
+		for fx := 0.05; fx < 1; fx += 0.1 {
+			want := sq(fx-0.5)+sq(fy-0.5) <= 0.25
+			assert.Equal(t, want, roundedContains(lone, 0.5, 0, 0, fx, fy), "(%g,%g)", fx, fy)
+		}
+	}
+
+	// Two diagonally touching modules stay apart at the shared corner, and
+	// an L gets a fillet in its empty corner.
+	diagonal := func(x, y int) bool { return x == y && x >= 0 && x <= 1 }
+	assert.False(t, roundedContains(diagonal, 0.3, 0, 0, 0.95, 0.95))
+	assert.False(t, roundedContains(diagonal, 0.3, 1, 0, 0.05, 0.95))
+	ell := func(x, y int) bool { return x >= 0 && y >= 0 && x <= 1 && y <= 1 && !(x == 1 && y == 1) }
+	assert.True(t, roundedContains(ell, 0.3, 1, 1, 0.05, 0.05))
+	assert.False(t, roundedContains(ell, 0.3, 1, 1, 0.25, 0.25))
+}
+
+func TestCornerRadiusValidation(t *testing.T) {
+	qr, err := EncodeText("INVALID", Low)
+	assert.NoError(t, err)
+	for _, r := range []float64{-0.1, 0.6, math.NaN()} {
+		_, err := qr.ToPNGBytes(NewQrCodeImgConfig(4, 2, WithCornerRadius(r)))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = qr.ToSVGBytes(NewQrCodeImgConfig(4, 2, WithCornerRadius(r)))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	}
+}
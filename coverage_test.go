@@ -73,15 +73,6 @@ func TestRunWorkersEdgeCases(t *testing.T) {
 	assert.Equal(t, 3, count)
 }
 
-// Covers logo.go eccRecoveryBudget every branch including default.
-func TestEccRecoveryBudgetAllBranches(t *testing.T) {
-	assert.InDelta(t, 0.05, eccRecoveryBudget(Low), 1e-9)
-	assert.InDelta(t, 0.12, eccRecoveryBudget(Medium), 1e-9)
-	assert.InDelta(t, 0.20, eccRecoveryBudget(Quartile), 1e-9)
-	assert.InDelta(t, 0.25, eccRecoveryBudget(High), 1e-9)
-	assert.InDelta(t, 0.05, eccRecoveryBudget(Ecc(99)), 1e-9) // default
-}
-
 // Covers logo.go logoRect error paths: bad sizeRatio, nil image, oversize.
 func TestLogoRectErrors(t *testing.T) {
 	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
@@ -113,12 +104,12 @@ func TestInjectSVGFragmentNoClosingTag(t *testing.T) {
 
 // Covers logo.go validate → ratio exceeds ECC budget.
 func TestLogoValidateExceedsBudget(t *testing.T) {
-	// Low ECC budget is 5%. A logo with sizeRatio 0.5 occupies ~25%+ of modules.
+	// Version 1-L corrects 3 codewords; a logo with sizeRatio 0.5 covers far more.
 	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
 	qr, err := EncodeText("hi", Low)
 	assert.NoError(t, err)
 	logo := &logoConfig{img: img, sizeRatio: 0.5}
-	assert.Error(t, logo.validate(qr, 10, 4))
+	assert.Error(t, logo.validate(qr))
 }
 
 // Covers logo.go svgEmbed happy path, producing <rect> + <image> fragment.
@@ -9,7 +9,15 @@ import (
 type SegmentInfo struct {
 	Mode     int    // 4-bit mode indicator (Numeric/Alphanumeric/Byte/...)
 	NumChars int    // character count from the segment header
-	Bytes    []byte // decoded bytes contributed by this segment
+	Bytes    []byte // decoded bytes contributed by this segment; the two raw header bytes for Structured Append
+}
+
+// StructuredAppendInfo is the Structured Append header of a symbol that is part
+// of a linked sequence.
+type StructuredAppendInfo struct {
+	Index  int  // 0-based position of this symbol in the sequence
+	Total  int  // number of symbols in the sequence
+	Parity byte // XOR of every byte of the complete message
 }
 
 // DecodeResult is the structured output of DecodeDetailed.
@@ -19,6 +27,16 @@ type DecodeResult struct {
 	Ecc      Ecc
 	Mask     int
 	Segments []SegmentInfo
+
+	// StructuredAppend is set when the symbol carries a Structured Append
+	// header; Text is then only this symbol's share of the message.
+	StructuredAppend *StructuredAppendInfo
+
+	// Padding holds the data codewords after the terminator, from the next
+	// byte boundary on: normally the 0xEC/0x11 pad pattern, or a payload
+	// written with WithHiddenPayload. It is empty when the message fills
+	// the symbol.
+	Padding []byte
 }
 
 type decodeConfig struct {
@@ -74,11 +92,22 @@ func DecodeDetailed(img image.Image, opts ...DecodeOption) (*DecodeResult, error
 		return nil, err
 	}
 
-	text, segs, err := parseBitstream(data, ver)
+	text, segs, padding, err := parseBitstream(data, ver)
 	if err != nil {
 		return nil, err
 	}
-	return &DecodeResult{Text: text, Version: ver, Ecc: ecl, Mask: mask, Segments: segs}, nil
+	res := &DecodeResult{Text: text, Version: ver, Ecc: ecl, Mask: mask, Segments: segs, Padding: padding}
+	for _, seg := range segs {
+		if seg.Mode == structuredAppend.modeBits {
+			res.StructuredAppend = &StructuredAppendInfo{
+				Index:  int(seg.Bytes[0] >> 4),
+				Total:  int(seg.Bytes[0]&0xF) + 1,
+				Parity: seg.Bytes[1],
+			}
+			break
+		}
+	}
+	return res, nil
 }
 
 // fastSample binarizes a crisp, axis-aligned image and samples it into a module
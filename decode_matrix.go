@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/piglig/go-qr/internal/reedsolomon"
+	"github.com/piglig/go-qr/qrspec"
 )
 
 // decodeMatrix takes a fully sampled module grid (modules[y][x] == dark) and
@@ -89,9 +90,7 @@ func readFormat(modules [][]bool, size int) (Ecc, int, error) {
 
 	formatVal := data >> 3 // 2 bits
 	mask := data & 0x7     // 3 bits
-	// eccFormats {Low:1, Medium:0, Quartile:3, High:2} is an involution, so the
-	// same table maps the 2-bit format value back to an Ecc.
-	ecl := Ecc(eccFormats[formatVal])
+	ecl := Ecc(qrspec.LevelFromFormatBits(formatVal))
 	return ecl, mask, nil
 }
 
@@ -99,10 +98,10 @@ func readFormat(modules [][]bool, size int) (Ecc, int, error) {
 // BCH(15,5) codewords by Hamming distance. Returns the 5-bit data and whether a
 // unique correction within 3 bit errors was found.
 func correctFormat(raw int) (int, bool) {
-	unmasked := raw ^ 0x5412
+	unmasked := raw ^ qrspec.FormatInfoMask
 	bestData, bestDist := -1, 99
 	for d := 0; d < 32; d++ {
-		code := formatBCH(d)
+		code := qrspec.FormatBCH(d)
 		dist := bitCount(code ^ unmasked)
 		if dist < bestDist {
 			bestDist, bestData = dist, d
@@ -114,16 +113,6 @@ func correctFormat(raw int) (int, bool) {
 	return 0, false
 }
 
-// formatBCH builds the 15-bit BCH codeword for a 5-bit format data value,
-// matching the encoder's polynomial (mask not applied here).
-func formatBCH(data int) int {
-	rem := data
-	for i := 0; i < 10; i++ {
-		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
-	}
-	return data<<10 | rem
-}
-
 func bitCount(x int) int {
 	n := 0
 	for x != 0 {
@@ -136,7 +125,7 @@ func bitCount(x int) int {
 // readCodewords reverses drawCodewords: walk columns right-to-left in pairs,
 // zig-zagging, reading 8 bits per codeword from non-function modules.
 func (q *builder) readCodewords() []byte {
-	n := getNumRawDataModules(q.version) / 8
+	n := qrspec.TotalCodewords(q.version)
 	data := make([]byte, n)
 	i := 0
 	for right := q.size - 1; right >= 1; right -= 2 {
@@ -167,9 +156,10 @@ func (q *builder) readCodewords() []byte {
 // blocks (reverse of addEccAndInterLeave), Reed-Solomon corrects each block,
 // and concatenates the corrected data codewords in order.
 func deinterleaveAndCorrect(raw []byte, ver int, ecl Ecc) ([]byte, error) {
-	numBlocks := int(numErrorCorrectionBlocks[ecl][ver])
-	blockEccLen := int(eccCodeWordsPerBlock[ecl][ver])
-	rawCodewords := getNumRawDataModules(ver) / 8
+	structure := qrspec.BlockStructure(ver, qrspec.Level(ecl))
+	numBlocks := structure.NumBlocks
+	blockEccLen := structure.ECCodewordsPerBlock
+	rawCodewords := qrspec.TotalCodewords(ver)
 
 	numShortBlocks := numBlocks - rawCodewords%numBlocks
 	shortBlockLen := rawCodewords / numBlocks
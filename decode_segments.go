@@ -28,12 +28,19 @@ func (r *bitReader) read(n int) (int, bool) {
 }
 
 // parseBitstream walks the segment structure (reverse of EncodeSegments) and
-// reconstructs the original string. Supports numeric, alphanumeric, byte, and
-// ECI (skipped) modes; kanji is reported as unsupported for now.
-func parseBitstream(data []byte, ver int) (string, []SegmentInfo, error) {
+// reconstructs the original string. Supports numeric, alphanumeric, byte,
+// Hanzi (GB 2312 subset) and ECI modes, and reads Structured Append headers
+// and FNC1 indicators; kanji is reported as unsupported for now. Byte segments
+// after an ECI designator for a known Charset are transcoded to UTF-8; without
+// one they are passed through.
+// After FNC1, '%' in alphanumeric segments decodes to the GS separator (0x1D)
+// and "%%" to '%'. padding is the data after the terminator, from the next
+// byte boundary on.
+func parseBitstream(data []byte, ver int) (text string, segs []SegmentInfo, padding []byte, err error) {
 	r := &bitReader{data: data}
 	var out []byte
-	var segs []SegmentInfo
+	fnc1 := false
+	cs := CharsetUTF8
 
 	for r.remaining() >= 4 {
 		modeBits, ok := r.read(4)
@@ -50,39 +57,76 @@ func parseBitstream(data []byte, ver int) (string, []SegmentInfo, error) {
 		case Byte.modeBits:
 			mode = Byte
 		case Eci.modeBits:
-			// ECI: read the assignment number and ignore it (byte mode here is
-			// already raw bytes / UTF-8 from the encoder).
-			if _, err := readECI(r); err != nil {
-				return "", nil, err
+			eci, err := readECI(r)
+			if err != nil {
+				return "", nil, nil, err
 			}
+			// Unknown assignments leave the bytes as they are.
+			if c, ok := charsetForECI(eci); ok {
+				cs = c
+			} else {
+				cs = CharsetUTF8
+			}
+			continue
+		case structuredAppend.modeBits:
+			header, ok := r.read(16)
+			if !ok {
+				return "", nil, nil, fmt.Errorf("%w: truncated Structured Append header", ErrDecodeFailed)
+			}
+			segs = append(segs, SegmentInfo{Mode: modeBits, Bytes: []byte{byte(header >> 8), byte(header)}})
+			continue
+		case fnc1First.modeBits:
+			fnc1 = true
+			continue
+		case fnc1Second.modeBits:
+			if _, ok := r.read(8); !ok {
+				return "", nil, nil, fmt.Errorf("%w: truncated FNC1 application indicator", ErrDecodeFailed)
+			}
+			fnc1 = true
 			continue
 		case Kanji.modeBits:
-			return "", nil, fmt.Errorf("%w: kanji segment decode not yet implemented", ErrUnsupportedSymbol)
+			return "", nil, nil, fmt.Errorf("%w: kanji segment decode not yet implemented", ErrUnsupportedSymbol)
+		case Hanzi.modeBits:
+			subset, ok := r.read(4)
+			if !ok {
+				return "", nil, nil, fmt.Errorf("%w: truncated hanzi subset", ErrDecodeFailed)
+			}
+			if subset != hanziSubsetGB2312 {
+				return "", nil, nil, fmt.Errorf("%w: hanzi subset %d", ErrUnsupportedSymbol, subset)
+			}
+			mode = Hanzi
 		default:
-			return "", nil, fmt.Errorf("%w: unknown mode 0x%x", ErrDecodeFailed, modeBits)
+			return "", nil, nil, fmt.Errorf("%w: unknown mode 0x%x", ErrDecodeFailed, modeBits)
 		}
 
 		count, ok := r.read(mode.numCharCountBits(ver))
 		if !ok {
-			return "", nil, fmt.Errorf("%w: truncated char count", ErrDecodeFailed)
+			return "", nil, nil, fmt.Errorf("%w: truncated char count", ErrDecodeFailed)
 		}
 
 		start := len(out)
-		var err error
 		switch {
 		case mode.isNumeric():
 			out, err = readNumeric(r, count, out)
 		case mode.isAlphanumeric():
 			out, err = readAlphanumeric(r, count, out)
+			if err == nil && fnc1 {
+				out = append(out[:start], unescapeFnc1(out[start:])...)
+			}
 		case mode.isByte():
 			out, err = readByte(r, count, out)
+			if err == nil && cs != CharsetUTF8 {
+				out = append(out[:start], cs.decode(out[start:])...)
+			}
+		case mode.isHanzi():
+			out, err = readHanzi(r, count, out)
 		}
 		if err != nil {
-			return "", nil, err
+			return "", nil, nil, err
 		}
 		segs = append(segs, SegmentInfo{Mode: modeBits, NumChars: count, Bytes: append([]byte(nil), out[start:]...)})
 	}
-	return string(out), segs, nil
+	return string(out), segs, append([]byte(nil), data[(r.pos+7)/8:]...), nil
 }
 
 func readECI(r *bitReader) (int, error) {
@@ -156,3 +200,20 @@ func readByte(r *bitReader, count int, out []byte) ([]byte, error) {
 	}
 	return out, nil
 }
+
+// unescapeFnc1 maps an FNC1 alphanumeric run back to data: "%%" is a literal
+// '%' and a single '%' is the GS separator.
+func unescapeFnc1(b []byte) []byte {
+	res := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] != '%' {
+			res = append(res, b[i])
+		} else if i+1 < len(b) && b[i+1] == '%' {
+			res = append(res, '%')
+			i++
+		} else {
+			res = append(res, gs1Separator)
+		}
+	}
+	return res
+}
@@ -105,7 +105,7 @@ func TestRSCorrectsErrors(t *testing.T) {
 	if err != nil {
 		t.Fatalf("decodeMatrix after corruption: %v", err)
 	}
-	text, _, err := parseBitstream(data, ver)
+	text, _, _, err := parseBitstream(data, ver)
 	if err != nil {
 		t.Fatalf("parse: %v", err)
 	}
@@ -1,5 +1,5 @@
 // Package go_qr generates QR codes (Model 2, versions 1-40, all four
-// error-correction levels) and renders them to PNG, SVG, or image.Image.
+// error-correction levels) and renders them to PNG, SVG, PDF, or image.Image.
 //
 // # Quick start
 //
@@ -20,7 +20,73 @@
 //   - WithSVGXMLHeader emits the XML + DOCTYPE prolog.
 //   - WithOptimalSVG emits a single <path> with fill-rule="evenodd"
 //     (smaller, connected regions merged into one path).
-//   - WithLogo embeds a centered logo, validated against the ECC budget.
+//   - WithModuleShape draws dark modules as circles, rounded squares,
+//     diamonds or joined bars, identically in PNG and SVG; function patterns
+//     stay square unless WithFunctionPatternShape is given.
+//   - WithEyeStyle / WithFinderEyeStyle draw the frame and pupil of the
+//     finder patterns with their own shape and color.
+//   - WithCornerRadius rounds the outline of connected dark regions: arcs at
+//     outer corners, concave fillets at inner ones, in SVG and PNG alike.
+//   - WithLinearGradient / WithRadialGradient fill dark modules with a
+//     gradient of GradientStops; every stop needs a 3:1 contrast ratio
+//     against the light color.
+//   - WithLogo embeds a centered logo. Every Reed-Solomon block must keep
+//     the codewords under the logo within its correction capacity;
+//     QrCode.LogoDamage reports the per-block damage and margin.
+//     WithShapedLogo takes a LogoSquare or LogoCircle outline, and
+//     EncodeForLogo searches version, ECC level and mask for a symbol the
+//     logo fits.
+//
+// # Encode options
+//
+// Encode(text, ...EncodeOption) exposes the EncodeSegments knobs as
+// functional options: WithEcc, WithMinVersion / WithMaxVersion, WithMask,
+// WithBoostEcl, WithSegmentation (SegmentationStandard or
+// SegmentationOptimal) and WithBinaryInput. With no options it matches
+// EncodeText(text, Low). MakeBinarySegmentsOptimally, also used for binary
+// input under SegmentationOptimal, moves digit and alphanumeric runs of
+// arbitrary bytes out of byte mode.
+//
+// QrSegment.Mode, NumChars and Bits read a segment back, and MakeRawSegment
+// rebuilds one from the same values, so segment lists can be inspected and
+// serialized.
+//
+// WithHiddenPayload writes caller bytes into the pad codewords after the
+// terminator, which scanners ignore; WithHiddenPayloadHMAC adds a length byte
+// and a truncated HMAC-SHA256 tag. DecodeResult.Padding returns the bytes
+// after the terminator and OpenHiddenPayload verifies a framed payload.
+//
+// # Capacity planning
+//
+// Plan(text, ecl, ...EncodeOption) reports the segments, bits per segment,
+// chosen version, capacity, headroom and ECC boost without rendering. When
+// the text does not fit it returns the plan at the largest allowed version
+// alongside ErrDataTooLong.
+//
+// SegmentBuilder assembles segments by hand, keeping the bit count of each
+// VersionClass as it goes; it reports the smallest version that fits at a
+// given level and refuses an append that would overflow its maximum version.
+//
+// # Symbol tables
+//
+// The qrspec subpackage publishes the tables the encoder and decoder share:
+// block structure per version and level, alignment pattern positions,
+// version and format information bits, and character capacities per mode.
+//
+// # Custom symbols
+//
+// SymbolBuilder makes scanner test vectors: it takes the data codewords of a
+// version and level (MakeDataCodewords) and a mask, and lets codewords after
+// interleaving, the format information and single modules be overridden
+// before Build returns the QrCode.
+//
+// # Mask selection
+//
+// WithMaskSelector hands the eight masked candidates, each with its N1-N4
+// penalty breakdown (MaskPenalty), to a MaskSelector. SpecMinimumMask is the
+// default rule, FixedMask pins one pattern, and LightRegionMask keeps a module
+// rectangle, such as the area under a logo, as light as possible.
+// QrCode.MaskPenalties re-scores a finished symbol to audit the choice.
 //
 // # In-memory rendering
 //
@@ -28,6 +94,70 @@
 // avoiding a file round-trip when writing to HTTP responses, archives, or
 // further image processing.
 //
+// # PDF output
+//
+// QrCode.PDF, WriteAsPDF and ToPDFBytes write a single-page vector PDF for
+// print: modules, eyes and rounded outlines are filled paths, gradients are
+// shadings and a logo is an image XObject. The page is scale points per
+// module, quiet zone included, unless WithPDFPageSize sets it in
+// millimetres; WithPDFCMYK writes DeviceCMYK instead of DeviceRGB colors.
+//
+// # Character sets
+//
+// EncodeText emits UTF-8 bytes with no ECI designator. EncodeTextCharset and
+// MakeSegmentsCharset transcode byte-mode runs into a target Charset
+// (ISO-8859-x, Shift_JIS, Windows-125x, UTF-8) and prepend the matching ECI
+// segment; MakeSegmentsAutoCharset picks the candidate charset with the
+// fewest total bits, ECI header included. The decoder honours ECI designators
+// for these charsets.
+//
+// MakeHanzi encodes GB 2312 Chinese in Hanzi mode (GB/T 18284), 13 bits per
+// character; MakeSegmentsOptimallyHanzi and SegmentationOptimalHanzi weigh it
+// against Kanji and byte mode, which MakeSegmentsOptimally does not, and the
+// decoder reads it back to UTF-8.
+//
+// # Module classification
+//
+// QrCode.ModuleKind reports whether a module belongs to a finder, separator,
+// timing, alignment, format, version or dark-module pattern, or carries data,
+// ECC or remainder bits. QrCode.ModuleLayout returns the full grid with the
+// codeword index and bit of every data and ECC module.
+//
+// # Image-shaped codes
+//
+// EncodeQArt makes the modules resemble a target image. The bits after the
+// terminator are solved for over GF(2), block by block, so that data and ECC
+// modules follow the picture, strongest contrast first, under the best of
+// the eight masks. The message and ECC are untouched and the symbol decodes
+// to the original text.
+//
+// # GS1
+//
+// EncodeGS1 and MakeGS1Segments validate GS1 element strings (Application
+// Identifier, length, character set, check digit) and emit them behind the
+// FNC1 first-position indicator. MakeFnc1First and MakeFnc1Second build the
+// FNC1 segments directly.
+//
+// # Structured Append
+//
+// EncodeStructuredAppend splits a payload too large for one symbol over up to
+// 16 QR Codes linked by Structured Append headers. DecodeDetailed reports the
+// header of each symbol in DecodeResult.StructuredAppend.
+//
+// # Micro QR
+//
+// EncodeMicroText and EncodeMicroSegments produce Micro QR symbols (M1-M4)
+// for labels too small for a version-1 QR Code. The result is an ordinary
+// *QrCode and renders through the same entry points; use QuietZone as the
+// border for the 2-module quiet zone Micro QR requires.
+//
+// # rMQR
+//
+// EncodeRMQRText and EncodeRMQRSegments produce rectangular Micro QR symbols
+// (ISO/IEC 23941, R7x43 to R17x139) for wide, short print areas. Width and
+// Height report the rectangle; the renderers size their output accordingly.
+// RMQRVersion looks up a version by its dimensions.
+//
 // # Batch API
 //
 // EncodeBatch and RenderBatch encode/render many inputs concurrently and
@@ -45,5 +175,10 @@
 // classify them with errors.Is:
 //
 //	ErrInvalidConfig, ErrInvalidArgument, ErrInvalidVersion,
-//	ErrDataTooLong, ErrUnencodableChar, ErrInvalidImageOutput
+//	ErrDataTooLong, ErrUnencodableChar, ErrInvalidImageOutput,
+//	ErrAuthFailed
+//
+// Capacity, character and logo budget failures are returned as
+// *DataTooLongError, *UnencodableCharError and *LogoBudgetError, whose fields
+// errors.As recovers.
 package go_qr
@@ -1,6 +1,10 @@
 package go_qr
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/piglig/go-qr/qrspec"
+)
 
 // EncodeText takes a string and an error correction level (ecl),
 // encodes the text to segments and returns a QR code or an error.
@@ -34,6 +38,19 @@ func EncodeStandardSegments(segs []*QrSegment, ecl Ecc) (*QrCode, error) {
 // the specification of minVer, maxVer, mask in addition to the regular parameters.
 // Returns a QR code object or an error.
 func EncodeSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer, mask int, boostEcl bool) (*QrCode, error) {
+	if mask < -1 || mask > 7 {
+		return nil, fmt.Errorf("%w: mask value out of range", ErrInvalidArgument)
+	}
+	var sel MaskSelector
+	if mask != -1 {
+		sel = fixedMask(mask)
+	}
+	return encodeSegments(segs, ecl, minVer, maxVer, sel, boostEcl)
+}
+
+// encodeSegments is EncodeSegments with the mask chosen by sel (nil for the
+// lowest-penalty mask).
+func encodeSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer int, sel MaskSelector, boostEcl bool) (*QrCode, error) {
 	if segs == nil {
 		return nil, fmt.Errorf("%w: segments slice is nil", ErrInvalidArgument)
 	}
@@ -42,31 +59,26 @@ func EncodeSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer, mask int, boostE
 		return nil, fmt.Errorf("%w: minVer=%d maxVer=%d", ErrInvalidVersion, minVer, maxVer)
 	}
 
-	// Loop over all versions between minVer and maxVer to find a suitable one
-	version, dataUsedBits := 0, 0
-	for version = minVer; ; version++ {
-		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
-		dataUsedBits = getTotalBits(segs, version)
-		if dataUsedBits != -1 && dataUsedBits <= dataCapacityBits {
-			break
-		}
-
-		if version >= maxVer {
-			if dataUsedBits != -1 {
-				return nil, fmt.Errorf("%w: data length %d bits exceeds capacity %d bits", ErrDataTooLong, dataUsedBits, dataCapacityBits)
-			}
-			return nil, fmt.Errorf("%w: segment too long", ErrDataTooLong)
-		}
+	version, dataUsedBits, err := fitVersion(segs, ecl, minVer, maxVer)
+	if err != nil {
+		return nil, err
+	}
+	if boostEcl {
+		ecl = boostEcc(ecl, version, dataUsedBits)
 	}
 
-	// If boostEcl is set, upgrade ECC as far as the data still fits.
-	for _, newEcl := range []Ecc{Medium, Quartile, High} {
-		numDataCodewords := getNumDataCodewords(version, newEcl)
-		if boostEcl && dataUsedBits <= numDataCodewords*8 {
-			ecl = newEcl
-		}
+	dataCodewords, _, err := makeDataCodewords(segs, version, ecl)
+	if err != nil {
+		return nil, err
 	}
+	return newQrCode(version, ecl, dataCodewords, sel)
+}
 
+// makeDataCodewords packs segs into the data codewords of version at ecl:
+// segment headers and data, the terminator, bit padding to a byte boundary
+// and alternating pad bytes. It also returns the number of bits up to and
+// including the terminator; every later bit is padding the decoder ignores.
+func makeDataCodewords(segs []*QrSegment, version int, ecl Ecc) ([]byte, int, error) {
 	bb := BitBuffer{}
 	for _, seg := range segs {
 		if seg == nil {
@@ -75,33 +87,39 @@ func EncodeSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer, mask int, boostE
 
 		err := bb.appendBits(seg.mode.modeBits, 4)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		if seg.mode.subset != 0 {
+			if err := bb.appendBits(seg.mode.subset, 4); err != nil {
+				return nil, 0, err
+			}
 		}
 		err = bb.appendBits(seg.numChars, seg.mode.numCharCountBits(version))
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		err = bb.appendData(seg.data)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 
 	dataCapacityBits := getNumDataCodewords(version, ecl) * 8
 	err := bb.appendBits(0, min(4, dataCapacityBits-bb.len()))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	terminated := bb.len()
 
 	err = bb.appendBits(0, (8-bb.len()%8)%8)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	for padByte := 0xEC; bb.len() < dataCapacityBits; padByte ^= 0xEC ^ 0x11 {
 		err = bb.appendBits(padByte, 8)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 
@@ -113,7 +131,37 @@ func EncodeSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer, mask int, boostE
 		}
 		dataCodewords[i>>3] |= byte(bit << (7 - (i & 7)))
 	}
-	return newQrCode(version, ecl, dataCodewords, mask)
+	return dataCodewords, terminated, nil
+}
+
+// fitVersion returns the smallest version in [minVer, maxVer] whose data
+// capacity at ecl holds segs, and the bits segs use there. On ErrDataTooLong
+// it still returns maxVer and its bit count (-1 when a segment overflows its
+// character count field).
+func fitVersion(segs []*QrSegment, ecl Ecc, minVer, maxVer int) (int, int, error) {
+	for version := minVer; ; version++ {
+		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+		dataUsedBits := getTotalBits(segs, version)
+		if dataUsedBits != -1 && dataUsedBits <= dataCapacityBits {
+			return version, dataUsedBits, nil
+		}
+
+		if version >= maxVer {
+			return version, dataUsedBits, &DataTooLongError{
+				RequiredBits: dataUsedBits, AvailableBits: dataCapacityBits, Version: version, Ecc: ecl}
+		}
+	}
+}
+
+// boostEcc returns the highest error correction level, no lower than ecl, at
+// which dataUsedBits still fit the version.
+func boostEcc(ecl Ecc, version, dataUsedBits int) Ecc {
+	for _, newEcl := range []Ecc{Medium, Quartile, High} {
+		if newEcl > ecl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+			ecl = newEcl
+		}
+	}
+	return ecl
 }
 
 // isValidVersion reports whether minVer and maxVer lie within [MinVersion, MaxVersion] and minVer <= maxVer.
@@ -123,6 +171,5 @@ func isValidVersion(minVer, maxVer int) bool {
 
 // getNumDataCodewords returns the number of data codewords for a given version and ECC level.
 func getNumDataCodewords(ver int, ecl Ecc) int {
-	return getNumRawDataModules(ver)/8 -
-		int(eccCodeWordsPerBlock[ecl][ver])*int(numErrorCorrectionBlocks[ecl][ver])
+	return qrspec.DataCodewords(ver, qrspec.Level(ecl))
 }
@@ -0,0 +1,170 @@
+package go_qr
+
+import "fmt"
+
+// Segmentation selects how Encode splits text into segments.
+type Segmentation int
+
+const (
+	// SegmentationStandard encodes the text as a single numeric, alphanumeric
+	// or byte segment, as MakeSegments does.
+	SegmentationStandard Segmentation = iota
+	// SegmentationOptimal switches modes mid-text wherever that saves bits,
+	// as MakeSegmentsOptimally does.
+	SegmentationOptimal
+	// SegmentationOptimalHanzi is SegmentationOptimal with Hanzi mode among
+	// the candidates, as MakeSegmentsOptimallyHanzi does. Not every reader
+	// supports Hanzi mode. Binary input is segmented as under
+	// SegmentationOptimal.
+	SegmentationOptimalHanzi
+)
+
+// encodeConfig holds the settings Encode passes to EncodeSegments.
+type encodeConfig struct {
+	ecl                    Ecc
+	minVersion, maxVersion int
+	mask                   int
+	maskSelector           MaskSelector
+	boostEcl               bool
+	segmentation           Segmentation
+	binary                 bool
+	hidden                 *hiddenPayload
+}
+
+// EncodeOption configures Encode.
+type EncodeOption func(*encodeConfig)
+
+// WithEcc sets the error correction level. The default is Low.
+func WithEcc(ecl Ecc) EncodeOption {
+	return func(c *encodeConfig) {
+		c.ecl = ecl
+	}
+}
+
+// WithMinVersion sets the smallest version Encode may choose. The default is
+// MinVersion.
+func WithMinVersion(version int) EncodeOption {
+	return func(c *encodeConfig) {
+		c.minVersion = version
+	}
+}
+
+// WithMaxVersion sets the largest version Encode may choose. The default is
+// MaxVersion.
+func WithMaxVersion(version int) EncodeOption {
+	return func(c *encodeConfig) {
+		c.maxVersion = version
+	}
+}
+
+// WithMask fixes the mask pattern (0-7). The default, -1, chooses the
+// lowest-penalty mask.
+func WithMask(mask int) EncodeOption {
+	return func(c *encodeConfig) {
+		c.mask = mask
+	}
+}
+
+// WithMaskSelector lets sel choose the mask from the eight scored candidates.
+// It takes precedence over WithMask.
+func WithMaskSelector(sel MaskSelector) EncodeOption {
+	return func(c *encodeConfig) {
+		c.maskSelector = sel
+	}
+}
+
+// WithBoostEcl controls whether the error correction level is raised as far
+// as the data still fits in the chosen version. It is on by default.
+func WithBoostEcl(boost bool) EncodeOption {
+	return func(c *encodeConfig) {
+		c.boostEcl = boost
+	}
+}
+
+// WithSegmentation sets the segmentation strategy. The default is
+// SegmentationStandard.
+func WithSegmentation(s Segmentation) EncodeOption {
+	return func(c *encodeConfig) {
+		c.segmentation = s
+	}
+}
+
+// WithBinaryInput encodes the bytes of text verbatim, without requiring valid
+// UTF-8. With SegmentationStandard they form one byte segment, as EncodeBinary
+// does; with SegmentationOptimal digit and alphanumeric runs are split out as
+// MakeBinarySegmentsOptimally does.
+func WithBinaryInput() EncodeOption {
+	return func(c *encodeConfig) {
+		c.binary = true
+	}
+}
+
+// Encode encodes text into a QR Code. With no options it behaves like
+// EncodeText(text, Low): versions 1-40, automatic mask, ECC boost and
+// standard segmentation.
+func Encode(text string, options ...EncodeOption) (*QrCode, error) {
+	c := &encodeConfig{
+		ecl:        Low,
+		minVersion: MinVersion,
+		maxVersion: MaxVersion,
+		mask:       -1,
+		boostEcl:   true,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	segs, err := c.segments(text)
+	if err != nil {
+		return nil, err
+	}
+	if c.hidden != nil {
+		return c.encodeHidden(segs)
+	}
+	if c.maskSelector != nil {
+		return encodeSegments(segs, c.ecl, c.minVersion, c.maxVersion, c.maskSelector, c.boostEcl)
+	}
+	return EncodeSegments(segs, c.ecl, c.minVersion, c.maxVersion, c.mask, c.boostEcl)
+}
+
+// validate checks the ECC level, version range and mask of the options.
+func (c *encodeConfig) validate() error {
+	if c.ecl < Low || c.ecl > High {
+		return fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, c.ecl)
+	}
+	if !isValidVersion(c.minVersion, c.maxVersion) {
+		return fmt.Errorf("%w: minVersion=%d maxVersion=%d", ErrInvalidVersion, c.minVersion, c.maxVersion)
+	}
+	if c.mask < -1 || c.mask > 7 {
+		return fmt.Errorf("%w: mask value out of range", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// segments splits text according to the configured input and strategy.
+func (c *encodeConfig) segments(text string) ([]*QrSegment, error) {
+	if c.binary && (c.segmentation == SegmentationOptimal || c.segmentation == SegmentationOptimalHanzi) {
+		return MakeBinarySegmentsOptimally([]byte(text), c.ecl, c.minVersion, c.maxVersion)
+	}
+	if c.binary {
+		seg, err := MakeBytes([]byte(text))
+		if err != nil {
+			return nil, err
+		}
+		return []*QrSegment{seg}, nil
+	}
+
+	switch c.segmentation {
+	case SegmentationStandard:
+		return MakeSegments(text)
+	case SegmentationOptimal:
+		return MakeSegmentsOptimally(text, c.ecl, c.minVersion, c.maxVersion)
+	case SegmentationOptimalHanzi:
+		return MakeSegmentsOptimallyHanzi(text, c.ecl, c.minVersion, c.maxVersion)
+	default:
+		return nil, fmt.Errorf("%w: unknown segmentation %d", ErrInvalidArgument, int(c.segmentation))
+	}
+}
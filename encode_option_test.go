@@ -0,0 +1,87 @@
+package go_qr
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDefaultsMatchEncodeText(t *testing.T) {
+	want, err := EncodeText("Hello, world! 123", Low)
+	assert.NoError(t, err)
+	got, err := Encode("Hello, world! 123")
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestEncodeOptions(t *testing.T) {
+	text := "HELLO"
+
+	qr, err := Encode(text, WithEcc(Low), WithBoostEcl(false))
+	assert.NoError(t, err)
+	assert.Equal(t, Low, qr.errorCorrectionLevel)
+	qr, err = Encode(text, WithEcc(Low))
+	assert.NoError(t, err)
+	assert.Equal(t, High, qr.errorCorrectionLevel)
+
+	qr, err = Encode(text, WithMinVersion(5), WithMask(3))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, qr.version)
+	assert.Equal(t, 3, qr.mask)
+
+	_, err = Encode(text, WithMaxVersion(1), WithEcc(High), WithBoostEcl(false))
+	assert.NoError(t, err)
+	_, err = Encode(text+text+text, WithMaxVersion(1), WithEcc(High))
+	assert.True(t, errors.Is(err, ErrDataTooLong), "%v", err)
+
+	_, err = Encode(text, WithMinVersion(10), WithMaxVersion(2))
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+	_, err = Encode(text, WithMask(8))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = Encode(text, WithSegmentation(Segmentation(7)))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestEncodeInvalidEcc(t *testing.T) {
+	// Every entry point built on the Encode options rejects an unknown level
+	// instead of panicking in the tables.
+	for _, ecl := range []Ecc{-1, 4, 9} {
+		_, err := Encode("hi", WithEcc(ecl))
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", err)
+		_, err = Encode("hi", WithEcc(ecl), WithHiddenPayload([]byte{1}))
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", err)
+		_, err = Plan("hi", ecl)
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", err)
+		_, _, err = EncodeForLogo("hi", 0.1, LogoSquare, WithEcc(ecl))
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", err)
+		_, err = EncodeQArt("hi", image.NewGray(image.Rect(0, 0, 4, 4)), WithEcc(ecl))
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", err)
+	}
+}
+
+func TestEncodeSegmentation(t *testing.T) {
+	// A long digit run inside lower-case text only pays off when segmented.
+	text := "order 123456789012345678901234567890 shipped"
+	standard, err := Encode(text, WithBoostEcl(false), WithMask(0))
+	assert.NoError(t, err)
+	optimal, err := Encode(text, WithBoostEcl(false), WithMask(0), WithSegmentation(SegmentationOptimal))
+	assert.NoError(t, err)
+	assert.Less(t, optimal.version, standard.version)
+
+	img, err := optimal.ToImage(NewQrCodeImgConfig(4, 4))
+	assert.NoError(t, err)
+	got, err := Decode(img)
+	assert.NoError(t, err)
+	assert.Equal(t, text, got)
+}
+
+func TestEncodeBinaryInput(t *testing.T) {
+	data := []byte{0x00, 0xFF, 0xC3, 0x28, '1', '2'}
+	want, err := EncodeBinary(data, Medium)
+	assert.NoError(t, err)
+	got, err := Encode(string(data), WithEcc(Medium), WithBinaryInput(), WithSegmentation(SegmentationOptimal))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
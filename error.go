@@ -1,6 +1,9 @@
 package go_qr
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Exported sentinel errors. Callers may use errors.Is to detect them without
 // relying on error string contents.
@@ -40,4 +43,81 @@ var (
 	// ErrUnsupportedSymbol is returned for symbols this decoder does not
 	// support (Micro QR, segment modes not yet implemented, etc.).
 	ErrUnsupportedSymbol = errors.New("go_qr: unsupported symbol")
+
+	// ErrAuthFailed is returned when a hidden payload is missing or its HMAC
+	// tag does not verify.
+	ErrAuthFailed = errors.New("go_qr: authentication failed")
 )
+
+// DataTooLongError reports how far segments missed the capacity of the largest
+// version an encoder was allowed to use. It matches ErrDataTooLong under
+// errors.Is; use errors.As to read the details.
+type DataTooLongError struct {
+	// RequiredBits is the length of the segments at Version, headers
+	// included, or -1 if a segment has more characters than its count field
+	// holds.
+	RequiredBits int
+	// AvailableBits is the data capacity of Version at Ecc.
+	AvailableBits int
+	// Version is the version the data was measured against: the largest one
+	// allowed for QR Codes, the largest offering Ecc for Micro QR (1-4 for
+	// M1-M4) and the roomiest allowed one for rMQR.
+	Version int
+	Ecc     Ecc
+}
+
+// Error implements the error interface.
+func (e *DataTooLongError) Error() string {
+	if e.RequiredBits < 0 {
+		return fmt.Sprintf("%v: segment too long for the character count of version %d", ErrDataTooLong, e.Version)
+	}
+	return fmt.Sprintf("%v: data length %d bits exceeds capacity %d bits of version %d at ecc level %d",
+		ErrDataTooLong, e.RequiredBits, e.AvailableBits, e.Version, e.Ecc)
+}
+
+// Unwrap returns ErrDataTooLong.
+func (e *DataTooLongError) Unwrap() error { return ErrDataTooLong }
+
+// ExcessBits returns RequiredBits - AvailableBits, or -1 if RequiredBits is
+// unknown.
+func (e *DataTooLongError) ExcessBits() int {
+	if e.RequiredBits < 0 {
+		return -1
+	}
+	return e.RequiredBits - e.AvailableBits
+}
+
+// UnencodableCharError reports the first character an encoding could not
+// represent. It matches ErrUnencodableChar under errors.Is.
+type UnencodableCharError struct {
+	Rune   rune
+	Offset int    // byte offset of Rune in the input string
+	Target string // the mode or charset, e.g. "numeric mode" or "Shift_JIS"
+}
+
+// Error implements the error interface.
+func (e *UnencodableCharError) Error() string {
+	return fmt.Sprintf("%v: %q at byte %d is not representable in %s", ErrUnencodableChar, e.Rune, e.Offset, e.Target)
+}
+
+// Unwrap returns ErrUnencodableChar.
+func (e *UnencodableCharError) Unwrap() error { return ErrUnencodableChar }
+
+// LogoBudgetError reports a logo that damages more codewords of a
+// Reed-Solomon block than its error correction can restore. It matches
+// ErrInvalidConfig under errors.Is.
+type LogoBudgetError struct {
+	Block    int // index of the worst block
+	Coverage int // codewords of Block damaged by the logo
+	Budget   int // codewords Block can lose, floor(ECC codewords / 2)
+	Ecc      Ecc
+}
+
+// Error implements the error interface.
+func (e *LogoBudgetError) Error() string {
+	return fmt.Sprintf("%v: logo damages %d codewords of RS block %d, exceeds ECC %v budget of %d (use a smaller sizeRatio or a higher ECC)",
+		ErrInvalidConfig, e.Coverage, e.Block, e.Ecc, e.Budget)
+}
+
+// Unwrap returns ErrInvalidConfig.
+func (e *LogoBudgetError) Unwrap() error { return ErrInvalidConfig }
@@ -2,6 +2,7 @@ package go_qr
 
 import (
 	"errors"
+	"image/color"
 	"strings"
 	"testing"
 )
@@ -17,3 +18,91 @@ func TestEncodeText_DataTooLongWrapsSentinel(t *testing.T) {
 		t.Errorf("expected errors.Is(err, ErrDataTooLong), got %v", err)
 	}
 }
+
+func TestDataTooLongError(t *testing.T) {
+	text := strings.Repeat("A", 200)
+	_, err := EncodeSegments(mustSegments(t, text), Medium, 1, 5, -1, false)
+	var e *DataTooLongError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *DataTooLongError, got %v", err)
+	}
+	// 4 + 9 + 11*100 bits against 5-M's 86 data codewords.
+	if e.RequiredBits != 1113 || e.AvailableBits != 688 || e.Version != 5 || e.Ecc != Medium {
+		t.Errorf("unexpected details %+v", e)
+	}
+	if e.ExcessBits() != 425 {
+		t.Errorf("ExcessBits = %d, want 425", e.ExcessBits())
+	}
+	if !errors.Is(err, ErrDataTooLong) {
+		t.Errorf("expected errors.Is(err, ErrDataTooLong)")
+	}
+
+	// Every capacity-bound encoder reports the details.
+	long := strings.Repeat("9", 8000)
+	for name, f := range map[string]func() error{
+		"EncodeText": func() error { _, err := EncodeText(long, Low); return err },
+		"Optimally":  func() error { _, err := MakeSegmentsOptimally(strings.Repeat("a1", 2000), High, 1, 40); return err },
+		"Micro":      func() error { _, err := EncodeMicroText(long, Low); return err },
+		"RMQR":       func() error { _, err := EncodeRMQRText(long, Medium); return err },
+		"EncodeForLogo": func() error {
+			_, _, err := EncodeForLogo(strings.Repeat("x", 100), 0.2, LogoSquare, WithMaxVersion(3))
+			return err
+		},
+	} {
+		if err := f(); !errors.As(err, &e) || !errors.Is(err, ErrDataTooLong) {
+			t.Errorf("%s: expected *DataTooLongError, got %v", name, err)
+		}
+	}
+}
+
+func TestUnencodableCharError(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func() error
+		r      rune
+		offset int
+	}{
+		{"numeric", func() error { _, err := MakeNumeric("12x4"); return err }, 'x', 2},
+		{"alphanumeric", func() error { _, err := MakeAlphanumeric("ABé"); return err }, 'é', 2},
+		{"kanji", func() error { _, err := MakeKanji("点a"); return err }, 'a', 3},
+		{"hanzi", func() error { _, err := MakeHanzi("啊b"); return err }, 'b', 3},
+		{"charset", func() error { _, err := CharsetISO8859_1.Encode("aé点"); return err }, '点', 3},
+		{"optimal charset", func() error {
+			_, err := MakeSegmentsCharset("aé点", CharsetISO8859_1, Low, 1, 40)
+			return err
+		}, '点', 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f()
+			var e *UnencodableCharError
+			if !errors.As(err, &e) {
+				t.Fatalf("expected *UnencodableCharError, got %v", err)
+			}
+			if e.Rune != tt.r || e.Offset != tt.offset {
+				t.Errorf("got %q at %d, want %q at %d", e.Rune, e.Offset, tt.r, tt.offset)
+			}
+			if !errors.Is(err, ErrUnencodableChar) {
+				t.Errorf("expected errors.Is(err, ErrUnencodableChar)")
+			}
+		})
+	}
+}
+
+func TestLogoBudgetError(t *testing.T) {
+	qr, err := EncodeText("Hello, world!", Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = qr.ToPNGBytes(NewQrCodeImgConfig(4, 4, WithLogo(makeTestLogo(40, 40, color.Black), 0.3)))
+	var e *LogoBudgetError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *LogoBudgetError, got %v", err)
+	}
+	if e.Coverage <= e.Budget || e.Ecc != qr.errorCorrectionLevel {
+		t.Errorf("unexpected details %+v", e)
+	}
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected errors.Is(err, ErrInvalidConfig)")
+	}
+}
@@ -0,0 +1,248 @@
+package go_qr
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// EyeShape is the outline of a finder pattern's frame or pupil.
+type EyeShape int
+
+const (
+	EyeSquare  EyeShape = iota // plain square, as the standard draws it
+	EyeRounded                 // square with rounded corners
+	EyeCircle                  // circle; the frame becomes a ring
+	EyeLeaf                    // two opposite corners fully rounded, on the diagonal through the symbol center
+)
+
+// valid reports whether s is one of the defined eye shapes.
+func (s EyeShape) valid() bool {
+	return s >= EyeSquare && s <= EyeLeaf
+}
+
+// Finder identifies one of the finder patterns. Micro QR and rMQR symbols
+// only have FinderTopLeft.
+type Finder int
+
+const (
+	FinderTopLeft Finder = iota
+	FinderTopRight
+	FinderBottomLeft
+)
+
+// EyeStyle styles one finder pattern: the frame is the outer 7×7 ring, the
+// pupil the inner 3×3 square. A nil color stands for the dark color, or the
+// gradient when one is set.
+type EyeStyle struct {
+	FrameShape EyeShape
+	FrameColor color.Color
+	PupilShape EyeShape
+	PupilColor color.Color
+}
+
+// WithEyeStyle styles all finder patterns with style. See
+// WithFinderEyeStyle.
+func WithEyeStyle(style EyeStyle) Option {
+	return func(q *QrCodeImgConfig) {
+		for f := FinderTopLeft; f <= FinderBottomLeft; f++ {
+			WithFinderEyeStyle(f, style)(q)
+		}
+	}
+}
+
+// WithFinderEyeStyle styles finder pattern f, replacing its modules in PNG,
+// ToImage and SVG output. Scanners locate a finder by its 1:1:3:1:1
+// proportions, so a rounded or circular eye with strong contrast still
+// reads; the pupil must stay darker than the light color.
+func WithFinderEyeStyle(f Finder, style EyeStyle) Option {
+	return func(q *QrCodeImgConfig) {
+		if q.eyes == nil {
+			q.eyes = map[Finder]EyeStyle{}
+		}
+		q.eyes[f] = style
+	}
+}
+
+// validEyes checks the finder and shape of every eye style.
+func (q *QrCodeImgConfig) validEyes() error {
+	for f, style := range q.eyes {
+		if f < FinderTopLeft || f > FinderBottomLeft {
+			return fmt.Errorf("%w: unknown finder %d", ErrInvalidConfig, f)
+		}
+		if !style.FrameShape.valid() || !style.PupilShape.valid() {
+			return fmt.Errorf("%w: unknown eye shape", ErrInvalidConfig)
+		}
+	}
+	return nil
+}
+
+// eye is a styled finder pattern placed on a symbol.
+type eye struct {
+	x, y         int // top-left module of the 7×7 pattern
+	frame, pupil eyeOutline
+	frameColor   color.Color // nil for the dark fill
+	pupilColor   color.Color // nil for the dark fill
+}
+
+// eyeOutline is a rectangle with a radius per corner (top-left, top-right,
+// bottom-right, bottom-left), in module units relative to its eye.
+type eyeOutline struct {
+	x0, y0, size float64
+	radii        [4]float64
+}
+
+// styledEyes returns the styled finder patterns of the symbol under config.
+func (q *QrCode) styledEyes(config *QrCodeImgConfig) []eye {
+	if len(config.eyes) == 0 {
+		return nil
+	}
+	origins := map[Finder][2]int{FinderTopLeft: {0, 0}}
+	if q.symbol == SymbolQR {
+		origins[FinderTopRight] = [2]int{q.size - 7, 0}
+		origins[FinderBottomLeft] = [2]int{0, q.size - 7}
+	}
+	var res []eye
+	for f := FinderTopLeft; f <= FinderBottomLeft; f++ {
+		style, ok := config.eyes[f]
+		origin, placed := origins[f]
+		if !ok || !placed {
+			continue
+		}
+		e := eye{x: origin[0], y: origin[1], frameColor: style.FrameColor, pupilColor: style.PupilColor}
+		e.frame = newEyeOutline(style.FrameShape, f, 0, 7)
+		e.pupil = newEyeOutline(style.PupilShape, f, 2, 3)
+		res = append(res, e)
+	}
+	return res
+}
+
+// newEyeOutline returns shape as a size×size outline at offset off in the
+// eye of finder f.
+func newEyeOutline(shape EyeShape, f Finder, off, size float64) eyeOutline {
+	o := eyeOutline{x0: off, y0: off, size: size}
+	switch shape {
+	case EyeRounded:
+		o.radii = [4]float64{size / 4, size / 4, size / 4, size / 4}
+	case EyeCircle:
+		o.radii = [4]float64{size / 2, size / 2, size / 2, size / 2}
+	case EyeLeaf:
+		if f == FinderTopLeft {
+			o.radii = [4]float64{size / 2, 0, size / 2, 0}
+		} else {
+			o.radii = [4]float64{0, size / 2, 0, size / 2}
+		}
+	}
+	return o
+}
+
+// inset returns the outline shrunk by d on every side, corner radii
+// included, for the hole of a frame.
+func (o eyeOutline) inset(d float64) eyeOutline {
+	res := eyeOutline{x0: o.x0 + d, y0: o.y0 + d, size: o.size - 2*d}
+	for i, r := range o.radii {
+		res.radii[i] = max(r-d, 0)
+	}
+	return res
+}
+
+// contains reports whether (px, py), in module units relative to the eye,
+// lies inside the outline.
+func (o eyeOutline) contains(px, py float64) bool {
+	x, y := px-o.x0, py-o.y0
+	if x < 0 || y < 0 || x > o.size || y > o.size {
+		return false
+	}
+	// Left and top sides of each corner, in the same order as radii.
+	left := [4]bool{true, false, false, true}
+	top := [4]bool{true, true, false, false}
+	for i, r := range o.radii {
+		cx, cy := r, r
+		if !left[i] {
+			cx = o.size - r
+		}
+		if !top[i] {
+			cy = o.size - r
+		}
+		inCorner := (x < cx) == left[i] && (y < cy) == top[i]
+		if r > 0 && inCorner && sq(x-cx)+sq(y-cy) > r*r {
+			return false
+		}
+	}
+	return true
+}
+
+// covers reports whether the module at (x, y) belongs to the eye's 7×7
+// pattern.
+func (e *eye) covers(x, y int) bool {
+	return x >= e.x && x < e.x+7 && y >= e.y && y < e.y+7
+}
+
+// eyeAt returns the eye covering the module at (x, y), or nil.
+func eyeAt(eyes []eye, x, y int) *eye {
+	for i := range eyes {
+		if eyes[i].covers(x, y) {
+			return &eyes[i]
+		}
+	}
+	return nil
+}
+
+// colorAt reports whether the point (px, py), in module units relative to
+// the eye, is painted and with which color; a nil color is the dark fill.
+func (e *eye) colorAt(px, py float64) (color.Color, bool) {
+	switch {
+	case e.pupil.contains(px, py):
+		return e.pupilColor, true
+	case e.frame.contains(px, py) && !e.frame.inset(1).contains(px, py):
+		return e.frameColor, true
+	}
+	return nil, false
+}
+
+// writeSVG appends one <path> for the frame and one for the pupil, origin
+// being the eye's top-left corner in SVG user units. darkFill is the fill of
+// parts without a color of their own.
+func (e *eye) writeSVG(sb *strings.Builder, ox, oy, scale float64, darkFill string) {
+	fill := func(c color.Color) string {
+		if c == nil {
+			return darkFill
+		}
+		return colorToSVGHex(c)
+	}
+	sb.WriteString("\t<path d=\"")
+	e.frame.writePath(svgPath{sb}, ox, oy, scale)
+	e.frame.inset(1).writePath(svgPath{sb}, ox, oy, scale)
+	sb.WriteString("\" fill=\"")
+	sb.WriteString(fill(e.frameColor))
+	sb.WriteString("\" fill-rule=\"evenodd\"/>\n")
+
+	sb.WriteString("\t<path d=\"")
+	e.pupil.writePath(svgPath{sb}, ox, oy, scale)
+	sb.WriteString("\" fill=\"")
+	sb.WriteString(fill(e.pupilColor))
+	sb.WriteString("\"/>\n")
+}
+
+// writePath appends the outline as a closed clockwise subpath, origin being
+// the eye's top-left corner in output units.
+func (o eyeOutline) writePath(p pathWriter, ox, oy, scale float64) {
+	x0, y0 := ox+o.x0*scale, oy+o.y0*scale
+	s := o.size * scale
+	r := [4]float64{o.radii[0] * scale, o.radii[1] * scale, o.radii[2] * scale, o.radii[3] * scale}
+	arc := func(r, px, py float64) {
+		if r > 0 {
+			p.arcTo(r, px, py, true)
+		}
+	}
+	p.moveTo(x0+r[0], y0)
+	p.lineTo(x0+s-r[1], y0)
+	arc(r[1], x0+s, y0+r[1])
+	p.lineTo(x0+s, y0+s-r[2])
+	arc(r[2], x0+s-r[2], y0+s)
+	p.lineTo(x0+r[3], y0+s)
+	arc(r[3], x0, y0+s-r[3])
+	p.lineTo(x0, y0+r[0])
+	arc(r[0], x0+r[0], y0)
+	p.closePath()
+}
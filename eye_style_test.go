@@ -0,0 +1,105 @@
+package go_qr
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEyeStyleDecodes(t *testing.T) {
+	text := "https://example.com/eyes"
+	qr, err := EncodeText(text, Medium)
+	assert.NoError(t, err)
+	for _, shape := range []EyeShape{EyeSquare, EyeRounded, EyeCircle, EyeLeaf} {
+		style := EyeStyle{FrameShape: shape, PupilShape: shape}
+		img, err := qr.ToImage(NewQrCodeImgConfig(8, 4, WithEyeStyle(style)))
+		assert.NoError(t, err)
+		decoded, err := Decode(img)
+		assert.NoError(t, err, "shape %d", shape)
+		assert.Equal(t, text, decoded, "shape %d", shape)
+	}
+}
+
+func TestEyeStyleSquareMatchesPlain(t *testing.T) {
+	qr, err := EncodeText("SQUARE EYES", Low)
+	assert.NoError(t, err)
+	plain, err := qr.ToImage(NewQrCodeImgConfig(4, 2))
+	assert.NoError(t, err)
+	styled, err := qr.ToImage(NewQrCodeImgConfig(4, 2, WithEyeStyle(EyeStyle{})))
+	assert.NoError(t, err)
+	assert.Equal(t, plain.Pix, styled.Pix)
+}
+
+func TestEyeStyleColors(t *testing.T) {
+	qr, err := EncodeText("COLORS", Low)
+	assert.NoError(t, err)
+	const scale = 10
+	red := color.RGBA{R: 200, A: 255}
+	blue := color.RGBA{B: 200, A: 255}
+	black := color.RGBA{A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0,
+		WithFinderEyeStyle(FinderTopRight, EyeStyle{FrameShape: EyeCircle, FrameColor: red, PupilShape: EyeCircle, PupilColor: blue})))
+	assert.NoError(t, err)
+
+	right := (qr.Size() - 7) * scale
+	// Pupil center, ring and the corner cut off by the circle.
+	assert.Equal(t, blue, img.RGBAAt(right+7*scale/2, 7*scale/2))
+	assert.Equal(t, red, img.RGBAAt(right+7*scale/2, scale/2))
+	assert.Equal(t, white, img.RGBAAt(right, 0))
+	// The other finders keep the dark color and square corners.
+	assert.Equal(t, black, img.RGBAAt(0, 0))
+	assert.Equal(t, black, img.RGBAAt(0, (qr.Size()-1)*scale))
+}
+
+func TestEyeOutlineLeaf(t *testing.T) {
+	tl := newEyeOutline(EyeLeaf, FinderTopLeft, 0, 7)
+	assert.False(t, tl.contains(0.1, 0.1))
+	assert.True(t, tl.contains(6.9, 0.1))
+	assert.False(t, tl.contains(6.9, 6.9))
+
+	tr := newEyeOutline(EyeLeaf, FinderTopRight, 0, 7)
+	assert.True(t, tr.contains(0.1, 0.1))
+	assert.False(t, tr.contains(6.9, 0.1))
+
+	// The frame hole keeps the leaf shape one module in.
+	hole := tl.inset(1)
+	assert.Equal(t, [4]float64{2.5, 0, 2.5, 0}, hole.radii)
+	assert.Equal(t, 5.0, hole.size)
+}
+
+func TestEyeStyleSVG(t *testing.T) {
+	qr, err := EncodeText("SVG EYES", Low)
+	assert.NoError(t, err)
+	style := EyeStyle{FrameShape: EyeRounded, FrameColor: color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 255}, PupilShape: EyeCircle}
+	for _, optimal := range []bool{false, true} {
+		opts := []Option{WithEyeStyle(style)}
+		if optimal {
+			opts = append(opts, WithOptimalSVG())
+		}
+		svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, opts...))
+		assert.NoError(t, err)
+		s := string(svg)
+		assert.Equal(t, 3, strings.Count(s, `fill="#123456" fill-rule="evenodd"`), "optimal=%v", optimal)
+		// Top-left frame: a rounded 70-unit square with a 17.5 radius.
+		assert.Contains(t, s, "M21.5,4L56.5,4A17.5,17.5 0 0 1 74,21.5")
+		// Top-left pupil: a circle of radius 15 inside the frame.
+		assert.Contains(t, s, "M39,24L39,24A15,15 0 0 1 54,39")
+	}
+
+	// The optimized renderer still merges the remaining modules: the top-left
+	// finder's first row is gone from the merged path.
+	plain, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithOptimalSVG()))
+	assert.NoError(t, err)
+	styled, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithOptimalSVG(), WithEyeStyle(style)))
+	assert.NoError(t, err)
+	assert.Contains(t, string(plain), "M4,4H74")
+	assert.NotContains(t, string(styled), "M4,4H74")
+
+	_, err = qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithEyeStyle(EyeStyle{FrameShape: EyeShape(9)})))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = qr.ToPNGBytes(NewQrCodeImgConfig(10, 4, WithFinderEyeStyle(Finder(5), EyeStyle{})))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,180 @@
+package go_qr
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+)
+
+// minGradientContrast is the lowest WCAG contrast ratio a gradient stop may
+// have against the light color, the ratio asked of non-text graphics.
+const minGradientContrast = 3.0
+
+// svgGradientID is the id of the gradient definition in SVG output.
+const svgGradientID = "qr-gradient"
+
+// GradientStop is a color at Offset, from 0 at the start of a gradient to 1
+// at its end.
+type GradientStop struct {
+	Offset float64
+	Color  color.Color
+}
+
+// gradient is a dark-module fill that varies across the symbol.
+type gradient struct {
+	radial bool
+	angle  float64 // linear: direction in degrees, clockwise from pointing right
+	cx, cy float64 // radial: center as fractions of the symbol width and height
+	stops  []GradientStop
+}
+
+// WithLinearGradient fills dark modules with a linear gradient instead of the
+// dark color. The gradient runs through the symbol center in direction angle,
+// in degrees clockwise from left-to-right (90 is top-to-bottom), and spans
+// the symbol from corner to corner along that direction. Stops are sorted by
+// offset; each must contrast with the light color.
+func WithLinearGradient(angle float64, stops ...GradientStop) Option {
+	return func(q *QrCodeImgConfig) {
+		q.gradient = newGradient(gradient{angle: angle}, stops)
+	}
+}
+
+// WithRadialGradient fills dark modules with a radial gradient instead of the
+// dark color. cx and cy place the center as fractions of the symbol width and
+// height (0.5, 0.5 is the middle); offset 1 lies on the farthest corner.
+// Stops are sorted by offset; each must contrast with the light color.
+func WithRadialGradient(cx, cy float64, stops ...GradientStop) Option {
+	return func(q *QrCodeImgConfig) {
+		q.gradient = newGradient(gradient{radial: true, cx: cx, cy: cy}, stops)
+	}
+}
+
+// newGradient returns g with a sorted copy of stops.
+func newGradient(g gradient, stops []GradientStop) *gradient {
+	g.stops = append([]GradientStop{}, stops...)
+	sort.SliceStable(g.stops, func(i, j int) bool { return g.stops[i].Offset < g.stops[j].Offset })
+	return &g
+}
+
+// valid checks the geometry and stops of g. Stops need a contrast ratio of
+// at least minGradientContrast with light, or with white when light is
+// transparent.
+func (g *gradient) valid(light color.Color) error {
+	if math.IsNaN(g.angle) || math.IsInf(g.angle, 0) {
+		return fmt.Errorf("%w: gradient angle must be finite", ErrInvalidConfig)
+	}
+	if !(g.cx >= 0 && g.cx <= 1 && g.cy >= 0 && g.cy <= 1) {
+		return fmt.Errorf("%w: gradient center (%g,%g) outside the symbol", ErrInvalidConfig, g.cx, g.cy)
+	}
+	if len(g.stops) < 2 {
+		return fmt.Errorf("%w: gradient needs at least two stops, got %d", ErrInvalidConfig, len(g.stops))
+	}
+	if colorIsTransparent(light) {
+		light = color.White
+	}
+	for i, s := range g.stops {
+		if !(s.Offset >= 0 && s.Offset <= 1) {
+			return fmt.Errorf("%w: gradient stop %d offset %g outside [0,1]", ErrInvalidConfig, i, s.Offset)
+		}
+		if s.Color == nil {
+			return fmt.Errorf("%w: gradient stop %d has no color", ErrInvalidConfig, i)
+		}
+		if ratio := contrastRatio(s.Color, light); ratio < minGradientContrast {
+			return fmt.Errorf("%w: gradient stop %d contrast %.2f:1 against the light color is below %.0f:1",
+				ErrInvalidConfig, i, ratio, minGradientContrast)
+		}
+	}
+	return nil
+}
+
+// span returns the gradient vector for a symbol occupying the box at (x0, y0)
+// of size w×h: the ends of the gradient line for a linear gradient, the
+// center and a point at offset 1 for a radial one.
+func (g *gradient) span(x0, y0, w, h float64) (ax, ay, bx, by float64) {
+	if g.radial {
+		cx, cy := x0+g.cx*w, y0+g.cy*h
+		r := math.Hypot(max(g.cx, 1-g.cx)*w, max(g.cy, 1-g.cy)*h)
+		return cx, cy, cx + r, cy
+	}
+	dx, dy := math.Cos(g.angle*math.Pi/180), math.Sin(g.angle*math.Pi/180)
+	half := (w*math.Abs(dx) + h*math.Abs(dy)) / 2
+	cx, cy := x0+w/2, y0+h/2
+	return cx - dx*half, cy - dy*half, cx + dx*half, cy + dy*half
+}
+
+// offsetAt returns the gradient offset of the point (x, y) for the vector
+// from span.
+func (g *gradient) offsetAt(x, y, ax, ay, bx, by float64) float64 {
+	if g.radial {
+		r := bx - ax
+		if r == 0 {
+			return 0
+		}
+		return math.Hypot(x-ax, y-ay) / r
+	}
+	dx, dy := bx-ax, by-ay
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return 0
+	}
+	return ((x-ax)*dx + (y-ay)*dy) / l2
+}
+
+// colorAt returns the color at offset t, clamped to the first and last
+// stops, interpolating unpremultiplied sRGB components as SVG does.
+func (g *gradient) colorAt(t float64) color.Color {
+	first, last := g.stops[0], g.stops[len(g.stops)-1]
+	if t <= first.Offset {
+		return first.Color
+	}
+	if t >= last.Offset {
+		return last.Color
+	}
+	i := sort.Search(len(g.stops), func(i int) bool { return g.stops[i].Offset > t })
+	lo, hi := g.stops[i-1], g.stops[i]
+	f := (t - lo.Offset) / (hi.Offset - lo.Offset)
+	a := color.NRGBAModel.Convert(lo.Color).(color.NRGBA)
+	b := color.NRGBAModel.Convert(hi.Color).(color.NRGBA)
+	mix := func(u, v uint8) uint8 { return uint8(math.Round(float64(u) + (float64(v)-float64(u))*f)) }
+	return color.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
+
+// writeSVGDefs appends a <defs> element holding the gradient as
+// svgGradientID, in user space over the symbol box at (x0, y0) of size w×h.
+func (g *gradient) writeSVGDefs(sb *strings.Builder, x0, y0, w, h float64) {
+	ax, ay, bx, by := g.span(x0, y0, w, h)
+	sb.WriteString("\t<defs>\n\t\t")
+	if g.radial {
+		sb.WriteString(`<radialGradient id="` + svgGradientID + `" gradientUnits="userSpaceOnUse" cx="`)
+		writeFloat(sb, ax)
+		sb.WriteString(`" cy="`)
+		writeFloat(sb, ay)
+		sb.WriteString(`" r="`)
+		writeFloat(sb, bx-ax)
+	} else {
+		sb.WriteString(`<linearGradient id="` + svgGradientID + `" gradientUnits="userSpaceOnUse" x1="`)
+		writeFloat(sb, ax)
+		sb.WriteString(`" y1="`)
+		writeFloat(sb, ay)
+		sb.WriteString(`" x2="`)
+		writeFloat(sb, bx)
+		sb.WriteString(`" y2="`)
+		writeFloat(sb, by)
+	}
+	sb.WriteString("\">\n")
+	for _, s := range g.stops {
+		sb.WriteString("\t\t\t<stop offset=\"")
+		writeFloat(sb, s.Offset)
+		sb.WriteString("\" stop-color=\"")
+		sb.WriteString(colorToSVGHex(s.Color))
+		sb.WriteString("\"/>\n")
+	}
+	if g.radial {
+		sb.WriteString("\t\t</radialGradient>\n")
+	} else {
+		sb.WriteString("\t\t</linearGradient>\n")
+	}
+	sb.WriteString("\t</defs>\n")
+}
@@ -0,0 +1,135 @@
+package go_qr
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	navy   = color.RGBA{R: 0x00, G: 0x20, B: 0x60, A: 0xFF}
+	maroon = color.RGBA{R: 0x80, G: 0x00, B: 0x20, A: 0xFF}
+)
+
+func TestGradientDecodes(t *testing.T) {
+	text := "https://example.com/gradient"
+	qr, err := EncodeText(text, Medium)
+	assert.NoError(t, err)
+	stops := []GradientStop{{0, navy}, {0.5, color.Black}, {1, maroon}}
+	for _, opt := range []Option{WithLinearGradient(45, stops...), WithRadialGradient(0.5, 0.5, stops...)} {
+		img, err := qr.ToImage(NewQrCodeImgConfig(8, 4, opt))
+		assert.NoError(t, err)
+		decoded, err := Decode(img)
+		assert.NoError(t, err)
+		assert.Equal(t, text, decoded)
+	}
+}
+
+func TestGradientPNGColors(t *testing.T) {
+	qr, err := EncodeText("LINEAR", Low)
+	assert.NoError(t, err)
+	const scale = 10
+	img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0, WithLinearGradient(0, GradientStop{0, navy}, GradientStop{1, maroon})))
+	assert.NoError(t, err)
+	// Left to right: the top-left finder is navy-ish, the top-right one
+	// maroon-ish, and the light color is untouched.
+	left := img.RGBAAt(0, 0)
+	right := img.RGBAAt(qr.Size()*scale-1, 0)
+	assert.InDelta(t, navy.B, left.B, 2)
+	assert.InDelta(t, maroon.R, right.R, 2)
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, img.RGBAAt(scale+scale/2, scale+scale/2))
+}
+
+func TestGradientGeometry(t *testing.T) {
+	g := newGradient(gradient{angle: 90}, []GradientStop{{1, maroon}, {0, navy}})
+	// Stops are sorted.
+	assert.Equal(t, 0.0, g.stops[0].Offset)
+
+	// Top to bottom over a 100×50 box at (10, 10).
+	ax, ay, bx, by := g.span(10, 10, 100, 50)
+	assert.InDelta(t, 60, ax, 1e-9)
+	assert.InDelta(t, 10, ay, 1e-9)
+	assert.InDelta(t, 60, bx, 1e-9)
+	assert.InDelta(t, 60, by, 1e-9)
+	assert.InDelta(t, 0.5, g.offsetAt(0, 35, ax, ay, bx, by), 1e-9)
+
+	// A diagonal spans the box corner to corner.
+	g.angle = 45
+	ax, ay, bx, by = g.span(0, 0, 10, 10)
+	assert.InDelta(t, 0, g.offsetAt(0, 0, ax, ay, bx, by), 1e-9)
+	assert.InDelta(t, 1, g.offsetAt(10, 10, ax, ay, bx, by), 1e-9)
+
+	// Radial offset 1 reaches the farthest corner.
+	r := newGradient(gradient{radial: true, cx: 0.25, cy: 0.5}, g.stops)
+	ax, ay, bx, by = r.span(0, 0, 8, 8)
+	assert.InDelta(t, 1, r.offsetAt(8, 0, ax, ay, bx, by), 1e-9)
+	assert.InDelta(t, math.Hypot(6, 4), bx-ax, 1e-9)
+
+	// Colors interpolate between stops and clamp outside them.
+	assert.Equal(t, navy, g.colorAt(-1))
+	assert.Equal(t, maroon, g.colorAt(2))
+	assert.Equal(t, color.NRGBA{R: 0x40, G: 0x10, B: 0x40, A: 0xFF}, g.colorAt(0.5))
+}
+
+func TestGradientSVG(t *testing.T) {
+	qr, err := EncodeText("SVG GRADIENT", Low)
+	assert.NoError(t, err)
+	for _, optimal := range []bool{false, true} {
+		opts := []Option{WithRadialGradient(0.5, 0.5, GradientStop{0, navy}, GradientStop{1, maroon})}
+		if optimal {
+			opts = append(opts, WithOptimalSVG())
+		}
+		svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, opts...))
+		assert.NoError(t, err)
+		s := string(svg)
+		// 21 modules of 10 after a border of 4: center 109, radius to a corner.
+		assert.Contains(t, s, `<radialGradient id="qr-gradient" gradientUnits="userSpaceOnUse" cx="109" cy="109" r="148.492">`)
+		assert.Contains(t, s, `<stop offset="0" stop-color="#002060"/>`)
+		assert.Contains(t, s, `<stop offset="1" stop-color="#800020"/>`)
+		assert.Contains(t, s, `fill="url(#qr-gradient)"`)
+		assert.Less(t, strings.Index(s, "<defs>"), strings.Index(s, "<path"))
+	}
+
+	svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithLinearGradient(90, GradientStop{0, navy}, GradientStop{1, maroon}),
+		WithEyeStyle(EyeStyle{PupilColor: color.Black})))
+	assert.NoError(t, err)
+	s := string(svg)
+	assert.Contains(t, s, `x1="109" y1="4" x2="109" y2="214"`)
+	// Eye frames without a color follow the gradient; pupils keep theirs.
+	assert.Equal(t, 3, strings.Count(s, `fill="url(#qr-gradient)" fill-rule="evenodd"`))
+	assert.Equal(t, 3, strings.Count(s, `fill="#000000"`))
+}
+
+func TestGradientValidation(t *testing.T) {
+	qr, err := EncodeText("INVALID", Low)
+	assert.NoError(t, err)
+	for name, opt := range map[string]Option{
+		"one stop":      WithLinearGradient(0, GradientStop{0, navy}),
+		"offset":        WithLinearGradient(0, GradientStop{0, navy}, GradientStop{1.5, maroon}),
+		"nil color":     WithLinearGradient(0, GradientStop{0, navy}, GradientStop{1, nil}),
+		"angle":         WithLinearGradient(math.NaN(), GradientStop{0, navy}, GradientStop{1, maroon}),
+		"center":        WithRadialGradient(2, 0.5, GradientStop{0, navy}, GradientStop{1, maroon}),
+		"low contrast":  WithLinearGradient(0, GradientStop{0, navy}, GradientStop{1, color.RGBA{R: 0xC0, G: 0xC0, B: 0xC0, A: 0xFF}}),
+		"against light": WithRadialGradient(0.5, 0.5, GradientStop{0, navy}, GradientStop{1, maroon}),
+	} {
+		opts := []Option{opt}
+		if name == "against light" {
+			opts = append(opts, WithLight(color.RGBA{R: 0x30, G: 0x30, B: 0x50, A: 0xFF}))
+		}
+		_, err := qr.ToPNGBytes(NewQrCodeImgConfig(4, 2, opts...))
+		assert.ErrorIs(t, err, ErrInvalidConfig, name)
+		_, err = qr.ToSVGBytes(NewQrCodeImgConfig(4, 2, opts...))
+		assert.ErrorIs(t, err, ErrInvalidConfig, name)
+	}
+
+	// A transparent light color is checked as white.
+	_, err = qr.ToPNGBytes(NewQrCodeImgConfig(4, 2, WithLight(color.Transparent),
+		WithLinearGradient(0, GradientStop{0, navy}, GradientStop{1, maroon})))
+	assert.NoError(t, err)
+
+	assert.InDelta(t, 21, contrastRatio(color.Black, color.White), 1e-9)
+	assert.InDelta(t, 1, contrastRatio(navy, navy), 1e-9)
+}
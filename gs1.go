@@ -0,0 +1,248 @@
+package go_qr
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// gs1Separator is the GS character that terminates a variable-length element
+// string when another element follows.
+const gs1Separator = 0x1D
+
+// GS1Element is one GS1 element string: an Application Identifier and its
+// value, e.g. {AI: "01", Value: "09501101530003"}.
+type GS1Element struct {
+	AI    string
+	Value string
+}
+
+// gs1AIFormat describes the value an Application Identifier accepts.
+type gs1AIFormat struct {
+	numeric    bool // digits only (n) rather than the GS1 character set 82 (an)
+	minLen     int
+	maxLen     int
+	checkDigit bool // the last digit is a GS1 mod-10 check digit
+	date       bool // YYMMDD
+}
+
+var (
+	gs1N13Check = gs1AIFormat{numeric: true, minLen: 13, maxLen: 13, checkDigit: true}
+	gs1N14Check = gs1AIFormat{numeric: true, minLen: 14, maxLen: 14, checkDigit: true}
+	gs1N18Check = gs1AIFormat{numeric: true, minLen: 18, maxLen: 18, checkDigit: true}
+	gs1Date     = gs1AIFormat{numeric: true, minLen: 6, maxLen: 6, date: true}
+	gs1N17Check = gs1AIFormat{numeric: true, minLen: 17, maxLen: 17, checkDigit: true}
+	gs1N2       = gs1AIFormat{numeric: true, minLen: 2, maxLen: 2}
+	gs1N3       = gs1AIFormat{numeric: true, minLen: 3, maxLen: 3}
+	gs1N6       = gs1AIFormat{numeric: true, minLen: 6, maxLen: 6}
+	gs1NUpTo8   = gs1AIFormat{numeric: true, minLen: 1, maxLen: 8}
+	gs1AnUpTo20 = gs1AIFormat{minLen: 1, maxLen: 20}
+	gs1AnUpTo30 = gs1AIFormat{minLen: 1, maxLen: 30}
+	gs1AnUpTo70 = gs1AIFormat{minLen: 1, maxLen: 70}
+	gs1AnUpTo90 = gs1AIFormat{minLen: 1, maxLen: 90}
+)
+
+// gs1AIs lists the supported Application Identifiers. The 310n-369n measure
+// AIs are matched by their first three digits in gs1LookupAI.
+var gs1AIs = map[string]gs1AIFormat{
+	"00":   gs1N18Check, // SSCC
+	"01":   gs1N14Check, // GTIN
+	"02":   gs1N14Check, // GTIN of contained trade items
+	"10":   gs1AnUpTo20, // batch or lot number
+	"11":   gs1Date,     // production date
+	"12":   gs1Date,     // due date
+	"13":   gs1Date,     // packaging date
+	"15":   gs1Date,     // best before date
+	"16":   gs1Date,     // sell by date
+	"17":   gs1Date,     // expiration date
+	"20":   gs1N2,       // internal product variant
+	"21":   gs1AnUpTo20, // serial number
+	"22":   gs1AnUpTo20, // consumer product variant
+	"240":  gs1AnUpTo30, // additional product identification
+	"241":  gs1AnUpTo30, // customer part number
+	"250":  gs1AnUpTo30, // secondary serial number
+	"30":   gs1NUpTo8,   // variable count of items
+	"37":   gs1NUpTo8,   // count of trade items
+	"400":  gs1AnUpTo30, // customer's purchase order number
+	"401":  gs1AnUpTo30, // global identification number for consignment
+	"402":  gs1N17Check, // global shipment identification number
+	"410":  gs1N13Check, // ship to / deliver to GLN
+	"411":  gs1N13Check, // bill to / invoice to GLN
+	"412":  gs1N13Check, // purchased from GLN
+	"413":  gs1N13Check, // ship for / deliver for GLN
+	"414":  gs1N13Check, // physical location GLN
+	"415":  gs1N13Check, // invoicing party GLN
+	"420":  gs1AnUpTo20, // ship to postal code
+	"422":  gs1N3,       // country of origin
+	"8004": gs1AnUpTo30, // global individual asset identifier
+	"8005": gs1N6,       // price per unit of measure
+	"8018": gs1N18Check, // global service relation number
+	"8200": gs1AnUpTo70, // extended packaging URL
+	"90":   gs1AnUpTo30, // information mutually agreed between trading partners
+}
+
+// gs1FixedLengthPrefixes are the two-digit AI prefixes whose element strings
+// have a predefined length and so never need a GS separator.
+var gs1FixedLengthPrefixes = []string{
+	"00", "01", "02", "03", "04", "11", "12", "13", "14", "15", "16", "17",
+	"18", "19", "20", "31", "32", "33", "34", "35", "36", "41",
+}
+
+// gs1CharSet82 is the GS1 AI encodable character set 82 used by "an" values.
+const gs1CharSet82 = "!\"%&'()*+,-./0123456789:;<=>?ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+// gs1LookupAI returns the value format of ai.
+func gs1LookupAI(ai string) (gs1AIFormat, bool) {
+	if f, ok := gs1AIs[ai]; ok {
+		return f, true
+	}
+	// 310n-369n: trade and logistic measures, n = decimal point position.
+	if len(ai) == 4 && isNumeric(ai) && "310" <= ai[:3] && ai[:3] <= "369" {
+		return gs1N6, true
+	}
+	// 91-99: company internal information.
+	if len(ai) == 2 && "91" <= ai && ai <= "99" {
+		return gs1AnUpTo90, true
+	}
+	return gs1AIFormat{}, false
+}
+
+// validate checks the element's AI and value, including the check digit and
+// date where the AI defines one.
+func (e GS1Element) validate() error {
+	f, ok := gs1LookupAI(e.AI)
+	if !ok {
+		return fmt.Errorf("%w: unknown GS1 Application Identifier %q", ErrInvalidArgument, e.AI)
+	}
+	if n := len(e.Value); n < f.minLen || n > f.maxLen {
+		if f.minLen == f.maxLen {
+			return fmt.Errorf("%w: GS1 AI (%s) value must be %d characters, got %d", ErrInvalidArgument, e.AI, f.maxLen, n)
+		}
+		return fmt.Errorf("%w: GS1 AI (%s) value must be %d-%d characters, got %d", ErrInvalidArgument, e.AI, f.minLen, f.maxLen, n)
+	}
+	if f.numeric && !isNumeric(e.Value) {
+		return unencodableIn(e.Value, "0123456789", fmt.Sprintf("the numeric GS1 AI (%s) value", e.AI))
+	}
+	for i := 0; i < len(e.Value); i++ {
+		if strings.IndexByte(gs1CharSet82, e.Value[i]) < 0 {
+			r, _ := utf8.DecodeRuneInString(e.Value[i:])
+			return &UnencodableCharError{Rune: r, Offset: i, Target: fmt.Sprintf("the GS1 AI (%s) value", e.AI)}
+		}
+	}
+	if f.checkDigit {
+		body, last := e.Value[:len(e.Value)-1], e.Value[len(e.Value)-1]
+		if want := gs1CheckDigit(body); want != last {
+			return fmt.Errorf("%w: GS1 AI (%s) check digit is %c, want %c", ErrInvalidArgument, e.AI, last, want)
+		}
+	}
+	if f.date {
+		month := (e.Value[2]-'0')*10 + e.Value[3] - '0'
+		day := (e.Value[4]-'0')*10 + e.Value[5] - '0'
+		// Day 00 means the last day of the month.
+		if month < 1 || month > 12 || day > 31 {
+			return fmt.Errorf("%w: GS1 AI (%s) date %q is not YYMMDD", ErrInvalidArgument, e.AI, e.Value)
+		}
+	}
+	return nil
+}
+
+// gs1CheckDigit computes the GS1 mod-10 check digit of digits: weights 3 and 1
+// alternate from the rightmost digit.
+func gs1CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// GS1ElementString validates elements and concatenates them into a GS1
+// element string, inserting the GS separator (0x1D) after every
+// variable-length element that is followed by another.
+func GS1ElementString(elements []GS1Element) (string, error) {
+	if len(elements) == 0 {
+		return "", fmt.Errorf("%w: no GS1 elements", ErrInvalidArgument)
+	}
+	sb := strings.Builder{}
+	for i, e := range elements {
+		if err := e.validate(); err != nil {
+			return "", err
+		}
+		sb.WriteString(e.AI)
+		sb.WriteString(e.Value)
+		if i < len(elements)-1 && !gs1IsFixedLength(e.AI) {
+			sb.WriteByte(gs1Separator)
+		}
+	}
+	return sb.String(), nil
+}
+
+// gs1IsFixedLength reports whether ai has a predefined length.
+func gs1IsFixedLength(ai string) bool {
+	for _, p := range gs1FixedLengthPrefixes {
+		if strings.HasPrefix(ai, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// MakeGS1Segments validates elements and returns the segments of a GS1 QR
+// Code: the FNC1 first-position segment followed by the element string,
+// segmented with MakeSegmentsOptimally. Separators and literal '%' characters
+// are escaped as each segment's mode requires.
+func MakeGS1Segments(elements []GS1Element, ecl Ecc) ([]*QrSegment, error) {
+	data, err := GS1ElementString(elements)
+	if err != nil {
+		return nil, err
+	}
+	fnc1, err := MakeFnc1First()
+	if err != nil {
+		return nil, err
+	}
+
+	// When everything but the separators is alphanumeric, encode separators
+	// as '%' so they do not force a byte segment.
+	escaped := strings.NewReplacer("%", "%%", string(rune(gs1Separator)), "%").Replace(data)
+	if isAlphanumeric(escaped) {
+		segs, err := MakeSegmentsOptimally(escaped, ecl, MinVersion, MaxVersion)
+		if err != nil {
+			return nil, err
+		}
+		return append([]*QrSegment{fnc1}, segs...), nil
+	}
+
+	// Otherwise separators stay 0x1D in byte segments, and only literal '%'
+	// characters that land in alphanumeric segments need doubling.
+	segs, err := MakeSegmentsOptimally(data, ecl, MinVersion, MaxVersion)
+	if err != nil {
+		return nil, err
+	}
+	res := []*QrSegment{fnc1}
+	for _, seg := range segs {
+		// GS1 data is ASCII, so numChars counts bytes in every mode.
+		text := data[:seg.numChars]
+		data = data[seg.numChars:]
+		if seg.mode.isAlphanumeric() && strings.Contains(text, "%") {
+			if seg, err = MakeAlphanumeric(strings.ReplaceAll(text, "%", "%%")); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, seg)
+	}
+	return res, nil
+}
+
+// EncodeGS1 encodes elements as a GS1 QR Code at the given error correction
+// level (boosted when the data still fits).
+func EncodeGS1(elements []GS1Element, ecl Ecc) (*QrCode, error) {
+	segs, err := MakeGS1Segments(elements, ecl)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeStandardSegments(segs, ecl)
+}
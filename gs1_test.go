@@ -0,0 +1,79 @@
+package go_qr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGS1CheckDigit(t *testing.T) {
+	assert.Equal(t, byte('3'), gs1CheckDigit("0950110153000"))
+	assert.Equal(t, byte('1'), gs1CheckDigit("400638133393"))
+}
+
+func TestGS1ElementString(t *testing.T) {
+	got, err := GS1ElementString([]GS1Element{
+		{AI: "01", Value: "09501101530003"},
+		{AI: "10", Value: "AB-123"},
+		{AI: "17", Value: "261231"},
+		{AI: "3103", Value: "001250"},
+	})
+	assert.NoError(t, err)
+	// Only the variable-length lot number needs a separator.
+	assert.Equal(t, "0109501101530003"+"10AB-123\x1d"+"17261231"+"3103001250", got)
+
+	tests := []struct {
+		name string
+		elem GS1Element
+		want error
+	}{
+		{"unknown AI", GS1Element{AI: "05", Value: "1"}, ErrInvalidArgument},
+		{"bad check digit", GS1Element{AI: "01", Value: "09501101530004"}, ErrInvalidArgument},
+		{"wrong length", GS1Element{AI: "01", Value: "0950110153000"}, ErrInvalidArgument},
+		{"too long", GS1Element{AI: "10", Value: "123456789012345678901"}, ErrInvalidArgument},
+		{"non-numeric", GS1Element{AI: "30", Value: "12A"}, ErrUnencodableChar},
+		{"outside charset 82", GS1Element{AI: "21", Value: "AB#1"}, ErrUnencodableChar},
+		{"bad date", GS1Element{AI: "17", Value: "261331"}, ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GS1ElementString([]GS1Element{tt.elem})
+			assert.True(t, errors.Is(err, tt.want), "%v", err)
+		})
+	}
+}
+
+func TestMakeFnc1Second(t *testing.T) {
+	seg, err := MakeFnc1Second("37")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{37}, seg.data.data)
+
+	seg, err = MakeFnc1Second("a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{'a' + 100}, seg.data.data)
+
+	_, err = MakeFnc1Second("123")
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+// TestEncodeGS1RoundTrip checks that the separators and literal '%' survive
+// both the alphanumeric and the byte-mode escaping.
+func TestEncodeGS1RoundTrip(t *testing.T) {
+	cases := [][]GS1Element{
+		{{AI: "01", Value: "09501101530003"}, {AI: "10", Value: "LOT%7"}, {AI: "21", Value: "SN42"}},
+		{{AI: "01", Value: "09501101530003"}, {AI: "21", Value: "abc%X1"}, {AI: "90", Value: "Q%R"}},
+	}
+	for _, elems := range cases {
+		want, err := GS1ElementString(elems)
+		assert.NoError(t, err)
+
+		qr, err := EncodeGS1(elems, Medium)
+		assert.NoError(t, err)
+		img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+		assert.NoError(t, err)
+		got, err := Decode(img)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,77 @@
+package go_qr
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+)
+
+// hanziSubsetGB2312 is the Hanzi mode subset indicator for GB 2312.
+const hanziSubsetGB2312 = 0x1
+
+var (
+	hanziOnce        sync.Once
+	qrHanziToUnicode []rune
+	unicodeToQRHanzi map[rune]int
+)
+
+// loadHanzi unpacks packedQRHanziToUnicode on first use.
+func loadHanzi() {
+	hanziOnce.Do(func() {
+		packed, _ := base64.StdEncoding.DecodeString(packedQRHanziToUnicode)
+		qrHanziToUnicode = make([]rune, len(packed)/2)
+		unicodeToQRHanzi = make(map[rune]int, len(packed)/2)
+		for i := range qrHanziToUnicode {
+			c := rune(packed[2*i])<<8 | rune(packed[2*i+1])
+			qrHanziToUnicode[i] = c
+			if c != 0xFFFF {
+				unicodeToQRHanzi[c] = i
+			}
+		}
+	})
+}
+
+// isHanzi reports whether code point c is in GB 2312 and so encodable in
+// Hanzi mode.
+func isHanzi(c int) bool {
+	loadHanzi()
+	_, ok := unicodeToQRHanzi[rune(c)]
+	return ok
+}
+
+// MakeHanzi converts a string into a QR code segment in Hanzi mode (GB/T
+// 18284, GB 2312 subset), 13 bits per character. It returns an error if the
+// string contains characters outside GB 2312.
+func MakeHanzi(text string) (*QrSegment, error) {
+	loadHanzi()
+	bb := &BitBuffer{}
+	n := 0
+	for i, c := range text {
+		val, ok := unicodeToQRHanzi[c]
+		if !ok {
+			return nil, &UnencodableCharError{Rune: c, Offset: i, Target: "hanzi mode"}
+		}
+		if err := bb.appendBits(val, 13); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	return newQrSegment(Hanzi, n, bb)
+}
+
+// readHanzi decodes count 13-bit Hanzi values and appends them to out as
+// UTF-8.
+func readHanzi(r *bitReader, count int, out []byte) ([]byte, error) {
+	loadHanzi()
+	for i := 0; i < count; i++ {
+		v, ok := r.read(13)
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated hanzi segment", ErrDecodeFailed)
+		}
+		if v >= len(qrHanziToUnicode) || qrHanziToUnicode[v] == 0xFFFF {
+			return nil, fmt.Errorf("%w: invalid hanzi value 0x%x", ErrDecodeFailed, v)
+		}
+		out = append(out, string(qrHanziToUnicode[v])...)
+	}
+	return out, nil
+}
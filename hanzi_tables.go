@@ -0,0 +1,112 @@
+package go_qr
+
+// packedQRHanziToUnicode maps each 13-bit Hanzi mode value to its code point,
+// as big-endian uint16s in base64, generated from the GB 2312 mapping. Values
+// 0x000-0x3BF cover rows 0xA1-0xAA (symbols), 0x3C0 up rows 0xB0-0xF7
+// (hanzi); 0xFFFF marks unassigned values.
+const packedQRHanziToUnicode = "" +
+	"MAAwATACMPsCyQLHAKgwAzAFIBX/XiAWICYgGCAZIBwgHTAUMBUwCDAJMAowCzAMMA0wDjAPMBYwFzAQMBEAsQDXAPciNiInIigiESIPIioiKSIIIjciGiKlIiUiICMSIpkiKyIuImEiTCJIIj0iHSJgIm4ibyJkImUiHiI1IjQmQiZAALAgMiAzIQP/BACk/+D/4SAw" +
+	"AKchFiYGJgUlyyXPJc4lxyXGJaEloCWzJbIgOyGSIZAhkSGTMBP///////////////////////////////////////////////8kiCSJJIokiySMJI0kjiSPJJAkkSSSJJMklCSVJJYklySYJJkkmiSbJHQkdSR2JHckeCR5JHokeyR8JH0kfiR/JIAkgSSCJIMkhCSF" +
+	"JIYkhyRgJGEkYiRjJGQkZSRmJGckaCRp/////zIgMiEyIjIjMiQyJTImMicyKDIp/////yFgIWEhYiFjIWQhZSFmIWchaCFpIWoha////////////wH/Av8D/+X/Bf8G/wf/CP8J/wr/C/8M/w3/Dv8P/xD/Ef8S/xP/FP8V/xb/F/8Y/xn/Gv8b/xz/Hf8e/x//IP8h" +
+	"/yL/I/8k/yX/Jv8n/yj/Kf8q/yv/LP8t/y7/L/8w/zH/Mv8z/zT/Nf82/zf/OP85/zr/O/88/z3/Pv8//0D/Qf9C/0P/RP9F/0b/R/9I/0n/Sv9L/0z/Tf9O/0//UP9R/1L/U/9U/1X/Vv9X/1j/Wf9a/1v/XP9d/+P/////MEEwQjBDMEQwRTBGMEcwSDBJMEowSzBM" +
+	"ME0wTjBPMFAwUTBSMFMwVDBVMFYwVzBYMFkwWjBbMFwwXTBeMF8wYDBhMGIwYzBkMGUwZjBnMGgwaTBqMGswbDBtMG4wbzBwMHEwcjBzMHQwdTB2MHcweDB5MHowezB8MH0wfjB/MIAwgTCCMIMwhDCFMIYwhzCIMIkwijCLMIwwjTCOMI8wkDCRMJIwk///////////" +
+	"////////////////////////MKEwojCjMKQwpTCmMKcwqDCpMKowqzCsMK0wrjCvMLAwsTCyMLMwtDC1MLYwtzC4MLkwujC7MLwwvTC+ML8wwDDBMMIwwzDEMMUwxjDHMMgwyTDKMMswzDDNMM4wzzDQMNEw0jDTMNQw1TDWMNcw2DDZMNow2zDcMN0w3jDfMOAw4TDi" +
+	"MOMw5DDlMOYw5zDoMOkw6jDrMOww7TDuMO8w8DDxMPIw8zD0MPUw9v//////////////////////////A5EDkgOTA5QDlQOWA5cDmAOZA5oDmwOcA50DngOfA6ADoQOjA6QDpQOmA6cDqAOp/////////////////////wOxA7IDswO0A7UDtgO3A7gDuQO6A7sDvAO9" +
+	"A74DvwPAA8EDwwPEA8UDxgPHA8gDyf//////////////////////////////////////////////////////////////////////////////////////////////////////////BBAEEQQSBBMEFAQVBAEEFgQXBBgEGQQaBBsEHAQdBB4EHwQgBCEEIgQjBCQEJQQm" +
+	"BCcEKAQpBCoEKwQsBC0ELgQv////////////////////////////////////////BDAEMQQyBDMENAQ1BFEENgQ3BDgEOQQ6BDsEPAQ9BD4EPwRABEEEQgRDBEQERQRGBEcESARJBEoESwRMBE0ETgRP////////////////////////////////////////AQEA4QHO" +
+	"AOABEwDpARsA6AErAO0B0ADsAU0A8wHSAPIBawD6AdQA+QHWAdgB2gHcAPwA6v//////////////////////////MQUxBjEHMQgxCTEKMQsxDDENMQ4xDzEQMRExEjETMRQxFTEWMRcxGDEZMRoxGzEcMR0xHjEfMSAxITEiMSMxJDElMSYxJzEoMSn/////////////" +
+	"////////////////////////////////////////////////////////JQAlASUCJQMlBCUFJQYlByUIJQklCiULJQwlDSUOJQ8lECURJRIlEyUUJRUlFiUXJRglGSUaJRslHCUdJR4lHyUgJSElIiUjJSQlJSUmJSclKCUpJSolKyUsJS0lLiUvJTAlMSUyJTMlNCU1" +
+	"JTYlNyU4JTklOiU7JTwlPSU+JT8lQCVBJUIlQyVEJUUlRiVHJUglSSVKJUv/////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////" +
+	"////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////VUqWP1fDYyhUzlUJVMB2kXZMhTx37oJ+eI1yMZaY" +
+	"l41sKFuJT/pjCWaXXLiA+mhIgK5mAnbOUfllVnGsf/GIhFCyWWVhym+zgq1jTGJSU+1UJ3sGUWt1pF30YtSNy5d2YoqAGVddlzh/YnI4dn1nz3Z+ZEZPcI0lYtx6F2WRc+1kLGJzgiyYgWd/ckhibmLMTzR041NKUp5+ypCmXi5ohmmcgYB+0WjSeMWGjJVRUI2MJILe" +
+	"gN5TBYkSUmX/////hYSW+U/dWCGZcVudYrFipWa0jHmcjXIGZ294kWCyU1FTF4+IgMyNHZShUA1yyFkHYOtxGYirWVSC72cseyhdKX73dS1s9Y5mj/iQPJ87a9SRGXsUX3x4p4TWhT1r1WvZa9ZeAV6HdfmV7WVdXwpfxY+fWMGBwpB/lluXrY+5fxaNLGJBT79T2FNe" +
+	"j6iPqY+rkE1oB19qgZiIaJzWYYtSK3YqX2xljG/SbuhbvmRIUXVRsGfEThl5yZl8cLP/////dcVednO7g+BkrWLolLVs4lNaUsNkD5TCe5RPL14bgjaBFoGKbiRsyppzY1VTXFT6iGVX4E4NXgNrZXw/kOhgFmTmcxyIwWdQYk2NIndsjimRx19pg9yFIZkQU8KGlWuL" +
+	"YO1g6HB/gs2CMU7TbKeFz2TNfNlp/Wb5g0lTlXtWT6dRjG1LXEKObWPSU8mDLIM2Z+V4tGQ9W99clF3ui+dixmf0jHpkAGO6h0mZi4wXfyCU8k6nlhCYpGYMcxb/////VzpcHV44lX9Qf4CgU4JlXnVFVTFQIY2FYoSUnmcdVjJvbl3iVDVwko9mYm9kpGOjX3tviJD0" +
+	"geOPsFwYZmhf8WyJlkiNgYhsZJF58FfOalliEFRITlh6C2Dpb4SL2mJ/kB6ai3nkVAN19GMBUxlsYI/fXxuacIA7n39PiFw6jWR/xWWlcL1RRVGyhmtdB1ugYr2RbHV0jgx6IGEBe3lOx374d4VOEYHtUh1R+mpxU6iOh5UEls9uwZZkaVr/////eEBQqHfXZBCJ5lkE" +
+	"Y+Nd3Xp/aT1PIII5VZhOMnWuepdeYl6Kle9SG1Q5cIpjdpUkV4JmJWk/kYdVB23zfq+IImIzfvB1tYMoeMGWzI+eYUh094vNa2RSOo1QayGAaoRxVvFTBk7OThtR0XyXkYt8B0/Djn974XqcZGddFFCsgQZ2AXy5bex/4GdRW1hb+HjLZK5kE2OqYyuVGWQtj757VHYp" +
+	"YlNZJ1RGa3lQo2I0XiZrhk7jjTeIi1+FkC7/////YCCAPWLFTjlTVZD4Y7iAxmXmbC5PRmDubeGL3l85hstfU2MhUVqDYWhjUgBjY45IUBJcm3l3W/xSMHo7YLyQU3bXX7dfl3aEjmxwb3Z7e0l3qlHzkJNYJE9ObvSP6mVMextyxG2kf99a4WK1XpVXMISCeyxeHV8f" +
+	"kBJ/FJigY4Jux3iYcLlReJdbV6t1NU9DdThel2DmWWBtwGu/eIlT/JbVUctSAWOJVAqUk4wDjcxyOXifh3aP7YwNU+D/////TgF271PulImYdp8OlS1bmouiTiJOHFGshGNhwlKoaAtPl2BrUbttHlFcYpZll5ZhjEaQF3XYkP13Y2vScopy7Iv7WDV3eY1MZ1yVQICa" +
+	"XqZuIVmSeu937ZU7a7VlrX8OWAZRUZYfW/lYqVQojnJlZph/VuSUnXb+kEFjh1TGWRpZOlebjrJnNY36gjVSQWDwWBWG/lzonkVPxJidi7laJWB2U4RifJBPkQKZf2BpgAxRP4AzXBSZdW0xToz/////jTBT0X9ae09PEE5PlgBs1XPQheleBnVqf/tqCnf+lJJ+QVHh" +
+	"cOZTzY/UgwONKXKvmW1s21dKgrNluYCqYj+WMlmoTv+Lv366ZT6D8pdeVWGY3oClUyqL/VQggLpen2y4jTmCrJFaVClsG1IGfrdXX3EabH58iVlLTv1f/2EkfKpOMFwBZ6uHAlzwlQuYznWvcP2QIlGvfx2LvVlJUeRPW1QmWStld4CkW3VidmLCj5BeRWwfeyZPD0/Y" +
+	"Zw3/////bW5tqnmPiLFfF3UrYpqPhU/vkdxlp4EvgVFenIFQjXRSb4mGjUtZDVCFTtiWHHI2gXmNH1vMi6OWRFmHfxpUkFZ2Vg6L5WU5aYKUmXbWbolecnUYZ0Zn0Xr/gJ2NdmEfecZlYo1jUYhSGpSifziAm36yXJduL2dge9l2i5rYgY9/lHzVZB6VUHo/VEpU5WtM" +
+	"ZAFiCJ49gPN1mVJyl2mEW2g8huSWAZaUlOxOKlQEftloOY3fgBVm9F6af7n/////V8KAP2iXXeVlO1KfYG2fmk+bjqxRbFurXxNd6WxeYvGNIVFxlKlS/myfgt9y11eiZ4SNLVkfj5yDx1SVe41PMGy9W2RZ0Z8TU+SGypqojDeAoWVFmH5W+pbHUi503FJQW+FjAokC" +
+	"TlZi0GAqaPpRc1uYUaCJwnuhmYZ/UGDvcEyNL1FJXn+QG3RwicRXLXhFX1Kfn5X6j2ibPIvhdnhoQmfcjeqNNVI9j4pu2mjNlQWQ7Vb9Z5yI+Y/HVMj/////mrhbaW13bCZOpVuzmoeRY2GokK+X6VQrbbVb0lH9VYp/VX/wZLxjTWXxYb5gjXEKbFdsSVkvZ22CKljV" +
+	"Vo6MamvrkN1ZfYAXU/dtaVR1VZ2Dd4PPaDh5vlSMT1VUCHbSjImWAmyzbbiNa4kQnmSNOlY/ntF11V+IcuBgaFT8TqhqKohhYFKPcFTEcNiGeZ4/bSpbj18YfqJViU+vczRUPFOaUBlUDlR8Tk5f/XRaWPaEa4Dhh3Ry0HzKblb/////XyeGTlUsYqROkmyqYjeCsVTX" +
+	"U05zPm7RdTtSElMWi91p0F+KYABt7ldPayJzr2hTj9h/E2NiYKNVJHXqjGJxFW2jW6Zee4NSYUyexHj6h1d8J3aHUfBg9nFMZkNeTGBNjA5wcGMlj4lfvWBihtRW3mvBYJRhZ1NJYOBmZo0/ef1PGnDpbEeLs4vyftiDZGYPWlqbQm1RbfeMQW07Txlwa4O3YhZg0ZcN" +
+	"jSd5eFH7Vz5X+mc6dXh6PXnve5X/////gIyZZY/5b8CLpZ4hWex+6X8JVAlngWjYj5F8TZbGU8pgJXW+bHJTc1rJfqdjJFHggQpd8YTfYoBRgFtjTw55bVJCYLhtTlvEW8KLoYuwZeJfzJZFWZN+536qVglnt1k5T3NbtlKgg1qYio0+dTKUvlBHejxO92e2mn5awWt8" +
+	"dtFXWlwWezqV9HFOUXyAqYJwWXh/BIMnaMBn7HixeHdi42Nhe4BP7VJqUc+DUGnbknSN9Y0xicGVLnutTvb/////UGWCMFJRmW9uEG6Fbade+lD1WdxcBm1GbF91hoSLaGhZVouyUyCRcZZNhUlpEnkBcSaA9k6kkMptR5qEWgdWvGQFlPB360+lgRpy4YnSmXp/NH7e" +
+	"Un9lWZF1j3+Pg1PrepZj7WOldoZ5+IhXljZiKlKrgoJoVGdwY3d3a3rtbQF+04njWdBiEoXJgqV1TFAfTst1pYvrXEpd/ntLZaSR0U7KbSWJX30nlSZOxYwoj9uXc2ZLeYGP0XDsbXj/////XD1SsoNGUWKDDndbZnacuE6sYMp8vnyzfs9OlYtmZm+YiJdZWINlbJVc" +
+	"X4R1yZdWet963lHAcK96mGPqenZ+oHOWl+1ORXB4Tl2RUlOpZVFl54H8ggVUjlwxdZqXoGLYctl1vVxFmnmDylxAVIB36U4+bK6AWmLSY25d6FF3jd2OHpUvT/FT5WDncKxSZ2NQnkNaH1AmdzdTd37iZIVlK2KJY5hQFHI1iclRs4vAft1XR4PMlKdRm1QbXPv/////" +
+	"T8p6421akOGaj1WAVJZTYVSvXwBj6Wl3Ue9haFIKWCpS2FdOeA13C163YXd84GJbYpdOonCVgANi93Dkl2BXd4LbZ+9o9XjVmJd50VjzVLNT7240UUtSO1uii/6Ar1VDV6Zgc1dRVC16emBQW1Rjp2KgU+NiY1vHZ69U7XqfguaRd16TiORZOFeuYw6N6IDvV1d7d0+p" +
+	"X+tbvWs+UyF7UHLCaEZ3/3c2ZfdRtU6PdtRcv3qlhHVZTptBUID/////mYhhJ26DV2RmBmNGVvBi7GJpXtOWFFeDYslVh4chgUqPo1Vmg7FnZY1WhN1aamgPYuZ77pYRUXBvnIwwY/2JyGHSfwZwwm7ldAVplHL8XsqQzmcXbWpjXlKzcmKAAU9sWeWRanDZbZ1S0k5Q" +
+	"lveVbYV+eMp9L1EhV5JkwoCLfHts6mjxaV5Rt1OYaKhygZ7Oe/Fy+Hm7bxN0BmdOkcycpHk8g4mDVFQPaBdOPVOJUrF4PlOGUilQiE+LT9D/////deJ6y3ySbKWWtlKbdINU6U/pgFSDso/elXBeyWAcbZ9eGGVbgTiU/mBLcLx+w3yuUclogXyxgm9OJI+Gkc9mfk6u" +
+	"jAVkqYBKUNp1l3HOW+WPvW9mToZkgpVjXtZlmVIXiMJwyFKjcw50M2eXePeXFk40kLuc3m3LUduNQVQdYs5zsoPxlvafhJTDTzZ/mlHMcHWWdVytmIZT5k7kbpx0CWm0eGuZj3VZUhh2JG1BZ/NRbZ+ZgEtUmXs8er//////loZXhGLilkdpfFoEZAJ7028PlkuCplNi" +
+	"mIVekHCJY7NTZIZPnIGek3iMlzKN741Cnn9vXnmEX1WWRmIumnRUFZTdT6NlxVxlXGF/FYZRbC9fi3OHbuR+/1zmYxtbam7mU3VOcWOgdWVioY9uTyZO0WymfraLuoQdh7p/V5A7lSN7qZqhiPiEPW0bmoZ+3FmInrtzm3gBhoKabJqCVhtUF1fLTnCeplNWj8iBCXeS" +
+	"mZKG7m7hhRNm/GFibyv/////jCmCkoMrdvJsE1/Zg71zK4MFlRpr23fblMZTb4MCUZJePYyMjThOSHOrZ5pohZF2lwlxZGyhdwlakpVBa89/jmYnW9BZuVqaleiV907shAyEmWqsdt+VMHMbaKZbX3cvkZqXYXzcj/eMHF8lfHN52InFbMyHHFvGXkJoyXcgfvVRlVFN" +
+	"UslaKX8Fl2KC12PPd4SF0HnSbjpemVmZhRFwbWwRYr92v2VPYK+V/WYOh5+eI5TtVA1UfYwsZHj/////ZHmGEWohgZx46GRpm1RiuWcrg6tYqJ7YbKtvIFvelkyMC3JfZ9Bix3JhTqlZxmvNWJNmrl5VUt9hVWcodu53ZnJnekZi/1TqVFCUoJCjWhx+s2wWTkNZdoAQ" +
+	"WUhTV3U3lr5WymMggRFgfJX5bdZUYpmBUYVa6YD9Wa6XE1AqbOVcPGLfT2BTP4F7kAZuuoUrYshedHi+ZLVje1/1WhiRf54fXD9jT4BCW31VbpVKlU1thWCoZ+By3lHdW4H/////Yuds3nJbYm2Urn69gRNtU1GcXwRZdFKqYBJZc2aWhlB1n2MqYeZ874v6VOZrJ54l" +
+	"a7SF1VRVUHZspFVqjbRyLF4VYBV0NmLNY5JyTF+YbkNtPmUAb1h22HjQdvx1VFIkU9tOU16eZcGAKoDWYptUhlIocK6IjY3RbOFUeIDaV/mI9I1UlmqRTU9pbJtVt3bGeDBiqHD5b45fbYTsaNp4fHv3gahnC55PY2d4sFdveBKXOWJ5YqtSiHQ1a9f/////VWSBPnWy" +
+	"dq5TOXXeUPtcQYtse8dQT3JHmpeY2G8CdOJ5aGSHd6Vi/JiRjStUwYBYTlJXaoL5hA1ec1HtdPaLxFxPV2Fs/JiHWkZ4NJtEj+t8lVJWYlGU+k7Gg4aEYYPphLJX1Gc0VwNmbm1mjDFm3XARZx9rOmgWYhpZu04DUcRvBmfSbI9RdmjLWUdrZ3VmXQ6BEJ9QZdd5SHlB" +
+	"mpGNd1yCTl5PAVQvWVF4DFZobBSPxF8DbH1s44urY5D/////YHBtPXJ1YmaUjpTFU0OPwXt+Tt+MJk5+ntSUsZSzUk1vXJBjbUWMNFgRXUxrIGtJZ6pUW4FUf4xYmYU3XzpiompHlTllcmCEaGV3p05UT6hd55eYZKx/2FztT896jVIHgwROFGAveoOUpk+1TrJ55nQ0" +
+	"UuSCuWTSeb1b3WyBl1KPe2wiUD5Tf24FZM5mdGwwYMWYd4v3XoZ0PHp3ectOGJCxdANsQlbakUtsxY2LUzqGxmbyjq9cSJpxbiD/////U9ZaNp+LjaNTu1cImKdnQ5GbbMlRaHXKYvNyrFI4Up1/OnCUdjhTdJ5Kabd4bpbAiNl/pHE2ccNRiWfTdORY5GUYVreLqZl2" +
+	"YnB+1WD5cO1Y7E7BTrpfzZfnTvuLpFIDWYp+q2JUTs1l5WIOgziEyYNjh41xlG62W7l+0lGXY8ln1ICJgzmIFVESW3pZgo+xTnNsXVFliSWPb5YuhUp0XpUQlfBtpoLlXzFkkm0ShCiBbpzDWF6NW04JU8H/////Tx5lY2hRVdNOJ2QUmppia1rCdF+Ccm2paO5Q54OO" +
+	"eAJnQFI5bJl+sVC7VWVxXntbZlJzyoLrZ0lccVIgcX2Ia5XqllVkxY1hgbNVhGxVYkd/LliSTyRVRo1PZkxOClwaiPNoomNOeg1w54KNUvqX9lwRVOiQtX7NWWKNSobHggyCDY1mZERcBGFRbYl5Pou+eDd1M1R7TziOq23xWiB+xXlebIhboVp2dRqAvmFObhdY8HUf" +
+	"dSVyclNHfvP/////dwF221JpgNxXI14IWTFy7mW9bn+L11w4hnFTQXfzYv5l9k7AmN+GgFuei8ZT8nfiT39cTpp2WctfD3k6WOtOFmf/Toti7YqTkB1Sv2YvVdxWbJACTtVPjZHKmXBsD14CYENbpInGi9VlNmJLmZZbiFv/Y4hVLlPXdiZRfYUsZ6Jos2uKYpKPk1PU" +
+	"ghJt0XWPTmaNTltwcZ+Fr2aRZtl/cocAns2fIFxeZy+P8GgRZ19iDXrWWIVetmVwbzH/////YFVSN4ANZFSIcHUpXgVoE2L0lxxTzHI9jAFsNHdheg5ULnesmHqCHIv0eFVnFHDBZa9klVY2YB15wVP4Th1re4CGW/pV41bbTzpPPJlyXfNnfoA4YAKYgpABW4uLvIv1" +
+	"ZByCWGTeVf2Cz5FlT9d9IJAffJ9Q81hRbq9bv4vJgIOReISce5eGfZaLlo9+5ZrTeI5cgXpXkEKWp3lfW1ljX3sLhNForVUGfyl0EH0ilQFiQFhMTtZbg1l5WFT/////c21jHo5Ljg+AzoLUYqxT8GzwkV5ZKmABbHBXTWRKjSp2K27pV1tqgHXwb22MLYwIV2Zr74iS" +
+	"eLNjolP5cK1sZFhYZCpYAmjggZtVEHzWUBiOum3MjZ9w62OPbZtu1H7mhARoQ5ADbdiWdouoWVdyeYXkgX51vIqKaK9SVI4ilRFj0JiYjkRVfE9TZv9Wj2DVbZVSQ1xJWSlt+1hrdTB1HGBsghSBRmMRZ2GP4nc6jfONNJTBXhZThVQscMP/////bEBe91BcTq1erWM6" +
+	"gkeQGmhQkW53s1QMlNxfZHrlaHZjRXtSft9121B3YpVZNJAPUfh5w3qBVv5fkpAUbYJcYFcfVBBRVG5NVuJjqJiTgX+HFYkqkABUHlxvgcBi1mJYgTGeNZZAmm6afGktWaVi01U+YxZUx4bZbTxaA3TmiJxralkWjExfL25+c6mYfU44cPdbjHiXYz1mWnaWYMtbm1pJ" +
+	"TgeBVWxqc4tOoWeJf1FfgGX6Zxtf2FmEWgH/////Xc1frlNxl+aP3WhFVvRVL2DfTjpvTX70gseEDlnUTx9PKlw+fqxnKoUaVHN1T4DDVYKbT09Nbi2ME1wJYXBTa3YfbimGimWHlft+uVQ7ejN9CpXuVeF/wXTuYx2HF22hep1iEWWhU2dj4WyDXetUXJSoTkxsYYvs" +
+	"XEtl4IKcaKdUPlQ0a8trZk6UY0JTSIIeTw1PrldeYgqW/mZkcmlS/1KhYJ+L72YUcZlnkIl/eFJ3/WZwVjtUOJUhcnr/////egBgb14MYImBnVkVYNxxhHDvbqpsUHKAaoSIrV4tTmBas1WclONtF3z7lpliD37Gd46GflMjlx6PlmaHXOFPoHLtTgtTplkPVBNjgJUo" +
+	"UUhO2ZycfqRUuI0kiFSCN5XybY5fJlrMZj6WaXOwcy5Tv4F6mYV/oVuqlneWUH6/dvhTopV2mZl7sYlEblhOYX/UeWWL5mDzVM1Oq5h5XfdqYVDPVBGMYYQneF2XBFJKVO5Wo5UAbYhbtW3GZlP/////XA9bXWghgJZVeHsRZUhpVE6ba0eHTpeLU09jH2Q6kKplnIDB" +
+	"jBBRmWiwU3iH+WHIbMRs+4wiXFGFqoKvlQxrI4+bZbBf+1/DT+GIRWYfgWVzKWD6UXRSEVeLX2KQoohMkZJeeGdPYCdZ01FEUfaA+FMIbHmWxHGKTxFP7n+eZz1VxZUIecCIln7jWJ9iDJcAhlpWGJh7X5CLuITEkVdT2WXtXo91XGBkfW5af37qfu2PaVWnW6NgrGXL" +
+	"c4T/////kAl2Y3cpftqXdIWbW2Z6dJbqiEBSy3GPX6pl7IviW/uab13ha4lsW4uti6+QCo/FU4tivJ4mni1UQE4rgr1yWYacXRaIWW2vlsVU0U6ai7ZxCVS9lglw3235dtBOJXgUhxJcqV72igCYnJYOcI5sv1lEY6l3PIhNbxSCc1gwcdVTjHgalsFVAV9mcTBbtIwa" +
+	"moxrg1kuni9552doYmxPb3Whf4ptC5YzbCdO8HXSUXtoN28+kICBcFmWdHb/////ZEdcJ5BlepGMI1naVKyCAINviYGAAGkwVk6ANnI3kc5Rtk5fmHVjlk4aU/Zm84FLWRxtsk4AWPlTO2PWlPFPnU8KiGOYkFk3kFd5+07qgPB1kWyCW5xZ6F9daQWGgVAaXfJOWXfj" +
+	"TuWCemKRZhOQkVx5Tr9feYHGkDiAhHWrTqaI1GEPa8Vfxk5Jdspuoovji66MCovRXwJ//H/Mfs6DNYNrVuBrt5fzljRZ+1QflPZt61vFmW5cOV8VlpD/////U3CC8WoxWnSecF6UfyiDuYQkhCWDZ4dHj86NYnbIX3GYlnhsZiBU32LlT2OBw3XIXriWzY4KhvlUj2zz" +
+	"bYxsOGB/Usd1KF59TxhgoF/nXCR1MZCulMByuWy5bjiRSWcJU8tT809RkcmL8VPIXnyPwm3kTo52wmmGhl5hGoIGT1lP3pA+nHxhCW4dbhSWhU6IWjGW6E4OXH95uVuHi+1/vXOJV9+Ci5DBVAGQR1W7XOpfoWEIazJy8YCyion/////bXRb04jVmISMa5ptnjNuClGk" +
+	"UUNXo4iBU59j9I+VVu1UWFcGcz9ukH8Yj9yC0WE/YCiWYmbwfqaNio3DlKVcs3ykZwhgppYFgBhOkZDnUwCWaFFBj9CFdJFdZlWX9VtVUx14OGdCaD1UyXB+W7CPfVGNVyhUsWUSZoKNXo1DgQ+EbJBtfN9R/4X7Z6Nl6W+hhqSOgVZqkCB2gnB2ceWNI2LpUhls/Y08" +
+	"YA5YnmGOZv6NYGJOVbNuI2ctj2f/////lOGV+HcoaAVpqFSLTk1wuIvIZFhli1uFeoRQOlvod7tr4Yp5fJhsvnbPZamPl10tXFWGOGgIU2BiGHrZblt+/WofeuBfcG8zXyBjjG2oZ1ZOCF4QjSZO14DAdjSWnGLbZi1ifmy8jXVxZ39pUUaAh1PskG5imFTyhvCPmYAF" +
+	"lReFF4/ZbVlzzWWfdx91BHgngfuNHpSIT6ZnlXW5i8qXB2MvlUeWNYS4YyN3QV+BcvBOiWAUZXRi72tjZT//////Xid1x5DRi8GCnWedZS9UMYcYd+WAooECbEFOS37HgEx29GkNa5ZiZ1A8T4RXQGMHa2KNvlPqZeh+uF/XYxpjt4HzgfR/bl4cXNlSNmZ6eel6Go0o" +
+	"cJl11G7ebLt6kk4tdsVf4JSfiHd+yHnNgL+RzU7yTxeCH1RoXd5tMovMfKWPdICYXhpUknaxW5lmPJqkc+BoKobbZzFzKov4i9uQEHr5cNtxbmLEd6lWMU47hFdn8VKphsCNLpT4e1H/////T09s6Hldmntik3IqYv1OE3gWj2xksI1ae8ZoaV6EiMVZhmSeWO5ytmkO" +
+	"lSWP/Y1YV2B/AIwGUcZjSWLZU1NoTHQigwGRTFVEd0BwfG1KUXlUqI1EWf9uy23EW1x9K07UfH1u01tQgepuDVtXmwNo1Y4qW5d+/GA7frWQuY1wWU9jzXnfjbNTUmXPeVaLxZY7fsSUu36CVjSRiWcAf2pcCpB1Zihd5k9QZ95QWk9cV1Bep///////////////////" +
+	"To1ODFFAThBe/1NFThVOmE4emzJbbFZpTih5uk4/UxVOR1ktcjtTbmwQVt+A5JmXa9N3fp8XTjZOn58QTlxOaU6TgohbW1VsVg9OxFONU51To1OlU66XZY1dUxpT9VMmUy5TPo1cU2ZTY1ICUghSDlItUjNSP1JAUkxSXlJhUlyEr1J9UoJSgVKQUpNRgn9UTrtOw07J" +
+	"TsJO6E7hTutO3k8bTvNPIk9kTvVPJU8nTwlPK09eT2dlOE9aT13/////T19PV08yTz1Pdk90T5FPiU+DT49Pfk97T6pPfE+sT5RP5k/oT+pPxU/aT+NP3E/RT99P+FApUExP81AsUA9QLlAtT/5QHFAMUCVQKFB+UENQVVBIUE5QbFB7UKVQp1CpULpQ1lEGUO1Q7FDm" +
+	"UO5RB1ELTt1sPU9YT2VPzp+gbEZ8dFFuXf2eyZmYUYFZFFL5Uw2KB1MQUetZGVFVTqBRVk6ziG6IpE61gRSI0nmAWzSIA3+4UatRsVG9Ubz/////UcdRllGiUaWLoIumi6eLqou0i7WLt4vCi8OLy4vPi86L0ovTi9SL1ovYi9mL3Ivfi+CL5Ivoi+mL7ovwi/OL9ov5" +
+	"i/yL/4wAjAKMBIwHjAyMD4wRjBKMFIwVjBaMGYwbjBiMHYwfjCCMIYwljCeMKowrjC6ML4wyjDOMNYw2U2lTepYdliKWIZYxliqWPZY8lkKWSZZUll+WZ5ZslnKWdJaIlo2Wl5awkJeQm5CdkJmQrJChkLSQs5C2kLr/////kLiQsJDPkMWQvpDQkMSQx5DTkOaQ4pDc" +
+	"kNeQ25DrkO+Q/pEEkSKRHpEjkTGRL5E5kUORRlINWUJSolKsUq1SvlT/UtBS1lLwU99x7nfNXvRR9VH8my9Ttl8BdVpd71dMV6lXoVh+WLxYxVjRVylXLFcqVzNXOVcuVy9XXFc7V0JXaVeFV2tXhld8V3tXaFdtV3ZXc1etV6RXjFeyV89Xp1e0V5NXoFfVV9hX2lfZ" +
+	"V9JXuFf0V+9X+FfkV93/////WAtYDVf9V+1YAFgeWBlYRFggWGVYbFiBWIlYmliAmaifGWH/gnmCfYJ/go+CioKogoSCjoKRgpeCmYKrgriCvoKwgsiCyoLjgpiCt4KugsuCzILBgqmCtIKhgqqCn4LEgs6CpILhgwmC94Lkgw+DB4LcgvSC0oLYgwyC+4LTgxGDGoMG" +
+	"gxSDFYLggtWDHINRg1uDXIMIg5KDPIM0gzGDm4Negy+DT4NHg0ODX4NAgxeDYIMtgzqDM4Nmg2X/////g2iDG4Npg2yDaoNtg26DsIN4g7ODtIOgg6qDk4Ocg4WDfIO2g6mDfYO4g3uDmIOeg6iDuoO8g8GEAYPlg9hYB4QYhAuD3YP9g9aEHIQ4hBGEBoPUg9+ED4QD" +
+	"g/iD+YPqg8WDwIQmg/CD4YRchFGEWoRZhHOEh4SIhHqEiYR4hDyERoRphHaEjISOhDGEbYTBhM2E0ITmhL2E04TKhL+EuoTghKGEuYS0hJeE5YTjhQx1DYU4hPCFOYUfhTr/////hVaFO4T/hPyFWYVIhWiFZIVehXp3ooVDhXKFe4WkhaiFh4WPhXmFroWchYWFuYW3" +
+	"hbCF04XBhdyF/4YnhgWGKYYWhjxe/l8IWTxZQYA3WVVZWllYUw9cIlwlXCxcNGJMYmpin2K7Yspi2mLXYu5jImL2YzljS2NDY61j9mNxY3pjjmO0Y21jrGOKY2ljrmO8Y/Jj+GPgY/9jxGPeY85kUmPGY75kRWRBZAtkG2QgZAxkJmQhZF5khGRtZJb/////ZHpkt2S4" +
+	"ZJlkumTAZNBk12TkZOJlCWUlZS5fC1/SdRlfEVNfU/FT/VPpU+hT+1QSVBZUBlRLVFJUU1RUVFZUQ1QhVFdUWVQjVDJUglSUVHdUcVRkVJpUm1SEVHZUZlSdVNBUrVTCVLRU0lSnVKZU01TUVHJUo1TVVLtUv1TMVNlU2lTcVKlUqlSkVN1Uz1TeVRtU51UgVP1VFFTz" +
+	"VSJVI1UPVRFVJ1UqVWdVj1W1VUlVbVVBVVVVP1VQVTz/////VTdVVlV1VXZVd1UzVTBVXFWLVdJVg1WxVblViFWBVZ9VflXWVZFVe1XfVb1VvlWUVZlV6lX3VclWH1XRVetV7FXUVeZV3VXEVe9V5VXyVfNVzFXNVehV9VXkj5RWHlYIVgxWAVYkViNV/lYAVidWLVZY" +
+	"VjlWV1YsVk1WYlZZVlxWTFZUVoZWZFZxVmtWe1Z8VoVWk1avVtRW11bdVuFW9VbrVvlW/1cEVwpXCVccXg9eGV4UXhFeMV47Xjz/////XjdeRF5UXlteXl5hXIxcelyNXJBcllyIXJhcmVyRXJpcnFy1XKJcvVysXKtcsVyjXMFct1zEXNJc5FzLXOVdAl0DXSddJl0u" +
+	"XSRdHl0GXRtdWF0+XTRdPV1sXVtdb11dXWtdS11KXWlddF2CXZldnYxzXbddxV9zX3dfgl+HX4lfjF+VX5lfnF+oX61ftV+8iGJfYXKtcrBytHK3crhyw3LBcs5yzXLScuhy73LpcvJy9HL3cwFy83MDcvr/////cvtzF3MTcyFzCnMecx1zFXMiczlzJXMsczhzMXNQ" +
+	"c01zV3Ngc2xzb3N+ghtZJZjnWSRZApljmWeZaJlpmWqZa5lsmXSZd5l9mYCZhJmHmYqZjZmQmZGZk5mUmZVegF6RXotell6lXqBeuV61Xr5es41TXtJe0V7bXuhe6oG6X8RfyV/WX89gA1/uYARf4V/kX/5gBWAGX+pf7V/4YBlgNWAmYBtgD2ANYClgK2AKYD9gIWB4" +
+	"YHlge2B6YEL/////YGpgfWCWYJpgrWCdYINgkmCMYJtg7GC7YLFg3WDYYMZg2mC0YSBhJmEVYSNg9GEAYQ5hK2FKYXVhrGGUYadht2HUYfVf3ZazlemV65XxlfOV9ZX2lfyV/pYDlgSWBpYIlgqWC5YMlg2WD5YSlhWWFpYXlhmWGk4scj9iFWw1bFRsXGxKbKNshWyQ" +
+	"bJRsjGxobGlsdGx2bIZsqWzQbNRsrWz3bPhs8WzXbLJs4GzWbPps62zubLFs02zvbP7/////bTltJ20MbUNtSG0HbQRtGW0ObSttTW0ubTVtGm1PbVJtVG0zbZFtb22ebaBtXm2TbZRtXG1gbXxtY24abcdtxW3ebg5tv23gbhFt5m3dbdluFm2rbgxtrm4rbm5uTm5r" +
+	"brJuX26GblNuVG4ybiVuRG7fbrFumG7gby1u4m6lbqduvW67brdu1260bs9uj27Cbp9vYm9Gb0dvJG8VbvlvL282b0tvdG8qbwlvKW+Jb41vjG94b3JvfG96b9H/////b8lvp2+5b7Zvwm/hb+5v3m/gb+9wGnAjcBtwOXA1cE9wXluAW4RblVuTW6VbuHUvmp5kNFvk" +
+	"W+6JMFvwjkeLB4+2j9OP1Y/lj+6P5I/pj+aP84/okAWQBJALkCaQEZANkBaQIZA1kDaQLZAvkESQUZBSkFCQaJBYkGKQW2a5kHSQfZCCkIiQg5CLX1BfV19WX1hcO1SrXFBcWVtxXGNcZn+8XypfKV8tgnRfPJs7XG5ZgVmDWY1ZqVmqWaP/////WZdZylmrWZ5ZpFnS" +
+	"WbJZr1nXWb5aBVoGWd1aCFnjWdhZ+VoMWglaMlo0WhFaI1oTWkBaZ1pKWlVaPFpiWnWA7FqqWptad1p6Wr5a61qyWtJa1Fq4WuBa41rxWtZa5lrYWtxbCVsXWxZbMls3W0BcFVwcW1pbZVtzW1FbU1timnWad5p4mnqaf5p9moCagZqFmoiaipqQmpKak5qWmpiam5qc" +
+	"mp2an5qgmqKao5qlmqd+n36hfqN+pX6ofqn/////fq1+sH6+fsB+wX7Cfsl+y37MftB+1H7Xftt+4H7hfuh+637ufu9+8X7yfw1+9n76fvt+/n8BfwJ/A38Hfwh/C38Mfw9/EX8Sfxd/GX8cfxt/H38hfyJ/I38kfyV/Jn8nfyp/K38sfy1/L38wfzF/Mn8zfzVeenV/" +
+	"Xdt1PpCVc45zkXOuc6Jzn3PPc8Jz0XO3c7NzwHPJc8hz5XPZmHx0CnPpc+dz3nO6c/J0D3QqdFt0JnQldCh0MHQudCz/////dBt0GnRBdFx0V3RVdFl0d3RtdH50nHSOdIB0gXSHdIt0nnSodKl0kHSndNJ0upfql+uX7GdMZ1NnXmdIZ2lnpWeHZ2pnc2eYZ6dndWeo" +
+	"Z55nrWeLZ3dnfGfwaAln2GgKZ+lnsGgMZ9lntWfaZ7Nn3WgAZ8NnuGfiaA5nwWf9aDJoM2hgaGFoTmhiaERoZGiDaB1oVWhmaEFoZ2hAaD5oSmhJaClotWiPaHRod2iTaGtowmluaPxpH2kgaPn/////aSRo8GkLaQFpV2jjaRBpcWk5aWBpQmldaYRpa2mAaZhpeGk0" +
+	"acxph2mIac5piWlmaWNpeWmbaadpu2mraa1p1GmxacFpymnfaZVp4GmNaf9qL2ntahdqGGplafJqRGo+aqBqUGpbajVqjmp5aj1qKGpYanxqkWqQaqlql2qrczdzUmuBa4Jrh2uEa5Jrk2uNa5prm2uha6qPa49tj3GPco9zj3WPdo94j3ePeY96j3yPfo+Bj4KPhI+H" +
+	"j4v/////j42Pjo+Pj5iPmo7OYgtiF2IbYh9iImIhYiViJGIsged073T0dP91D3URdRNlNGXuZe9l8GYKZhlncmYDZhVmAHCFZvdmHWY0ZjFmNmY1gAZmX2ZUZkFmT2ZWZmFmV2Z3ZoRmjGanZp1mvmbbZtxm5mbpjTKNM402jTuNPY1AjUWNRo1IjUmNR41NjVWNWYnH" +
+	"icqJy4nMic6Jz4nQidFybnKfcl1yZnJvcn5yf3KEcotyjXKPcpJjCGMyY7D/////ZD9k2IAEa+pr82v9a/Vr+WwFbAdsBmwNbBVsGGwZbBpsIWwpbCRsKmwyZTVlVWVrck1yUnJWcjCGYlIWgJ+AnICTgLxnCoC9gLGAq4CtgLSAt4DngOiA6YDqgNuAwoDEgNmAzYDX" +
+	"ZxCA3YDrgPGA9IDtgQ2BDoDygPxnFYESjFqBNoEegSyBGIEygUiBTIFTgXSBWYFagXGBYIFpgXyBfYFtgWdYTVq1gYiBgoGRbtWBo4GqgcxnJoHKgbv/////gcGBpmskazdrOWtDa0ZrWZjRmNKY05jVmNmY2muzX0BrwonzZZCfUWWTZbxlxmXEZcNlzGXOZdJl1nCA" +
+	"cJxwlnCdcLtwwHC3cKtwsXDocMpxEHETcRZxL3ExcXNxXHFocUVxcnFKcXhxenGYcbNxtXGocaBx4HHUcedx+XIdcihwbHEYcWZxuWI+Yj1iQ2JIYkl5O3lAeUZ5SXlbeVx5U3laeWJ5V3lgeW95Z3l6eYV5inmaead5s1/RX9D/////YDxgXWBaYGdgQWBZYGNgq2EG" +
+	"YQ1hXWGpYZ1hy2HRYgaAgIB/bJNs9m38d/Z3+HgAeAl4F3gYeBFlq3gteBx4HXg5eDp4O3gfeDx4JXgseCN4KXhOeG14VnhXeCZ4UHhHeEx4anibeJN4mniHeJx4oXijeLJ4uXileNR42XjJeOx48nkFePR5E3kkeR55NJ+bnvme+578dvF3BHcNdvl3B3cIdxp3IncZ" +
+	"dy13Jnc1dzh3UHdRd0d3Q3dad2j/////d2J3ZXd/d413fXeAd4x3kXefd6B3sHe1d711OnVAdU51S3VIdVt1cnV5dYN/WH9hf1+KSH9of3R/cX95f4F/fnbNduWIMpSFlIaUh5SLlIqUjJSNlI+UkJSUlJeUlZSalJuUnJSjlKSUq5SqlK2UrJSvlLCUspS0lLaUt5S4" +
+	"lLmUupS8lL2Uv5TElMiUyZTKlMuUzJTNlM6U0JTRlNKU1ZTWlNeU2ZTYlNuU3pTflOCU4pTklOWU55TolOr/////lOmU65TulO+U85T0lPWU95T5lPyU/ZT/lQOVApUGlQeVCZUKlQ2VDpUPlRKVE5UUlRWVFpUYlRuVHZUelR+VIpUqlSuVKZUslTGVMpU0lTaVN5U4" +
+	"lTyVPpU/lUKVNZVElUWVRpVJlUyVTpVPlVKVU5VUlVaVV5VYlVmVW5VelV+VXZVhlWKVZJVllWaVZ5VolWmVapVrlWyVb5VxlXKVc5U6d+d37JbJedV57Xnjeet6Bl1HegN6AnoeehT/////ejl6N3pRns+ZpXpwdoh2jnaTdpl2pHTedOB1LJ4gniKeKJ4pniqeK54s" +
+	"njKeMZ42njieN545njqePp5BnkKeRJ5GnkeeSJ5JnkueTJ5OnlGeVZ5XnlqeW55cnl6eY55mnmeeaJ5pnmqea55snnGebZ5zdZJ1lHWWdaB1nXWsdaN1s3W0dbh1xHWxdbB1w3XCddZ1zXXjdeh15nXkdet153YDdfF1/HX/dhB2AHYFdgx2F3YKdiV2GHYVdhn/////" +
+	"dht2PHYidiB2QHYtdjB2P3Y1dkN2PnYzdk12XnZUdlx2VnZrdm9/ynrmenh6eXqAeoZ6iHqVeqZ6oHqseqh6rXqziGSIaYhyiH2If4iCiKKIxoi3iLyIyYjiiM6I44jliPGJGoj8iOiI/ojwiSGJGYkTiRuJCok0iSuJNolBiWaJe3WLgOV2sna0d9yAEoAUgBaAHIAg" +
+	"gCKAJYAmgCeAKYAogDGAC4A1gEOARoBNgFKAaYBxiYOYeJiAmIP/////mImYjJiNmI+YlJiamJuYnpifmKGYopilmKaGTYZUhmyGboZ/hnqGfIZ7hqiGjYaLhqyGnYanhqOGqoaThqmGtobEhrWGzoawhrqGsYavhsmGz4a0humG8Ybyhu2G84bQhxOG3ob0ht+G2IbR" +
+	"hwOHB4b4hwiHCocNhwmHI4c7hx6HJYcuhxqHPodIhzSHMYcphzeHP4eChyKHfYd+h3uHYIdwh0yHboeLh1OHY4d8h2SHWYdlh5OHr4eoh9L/////h8aHiIeFh62Hl4eDh6uH5Yesh7WHs4fLh9OHvYfRh8CHyofbh+qH4IfuiBaIE4f+iAqIG4ghiDmIPH82f0J/RH9F" +
+	"ghB6+nr9ewh7A3sEexV7Cnsrew97R3s4eyp7GXsuezF7IHsleyR7M3s+ex57WHtae0V7dXtMe117YHtue3t7Yntye3F7kHume6d7uHuse517qHuFe6p7nHuie6t7tHvRe8F7zHvde9p75Xvme+p8DHv+e/x8D3wWfAv/////fB98KnwmfDh8QXxAgf6CAYICggSB7IhE" +
+	"giGCIoIjgi2CL4IogiuCOII7gjOCNII+gkSCSYJLgk+CWoJfgmiIfoiFiIiI2IjfiV5/nX+ff6d/r3+wf7J8fGVJfJF8nXycfJ58onyyfLx8vXzBfMd8zHzNfMh8xXzXfOiCbmaof79/zn/Vf+V/4X/mf+l/7n/zfPh9d32mfa5+R36bnrietI1zjYSNlI2RjbGNZ41t" +
+	"jEeMSZFKkVCRTpFPkWT/////kWKRYZFwkWmRb5F9kX6RcpF0kXmRjJGFkZCRjZGRkaKRo5Gqka2RrpGvkbWRtJG6jFWefo24jeuOBY5ZjmmNtY2/jbyNuo3EjdaN143ajd6Nzo3PjduNxo3sjfeN+I3jjfmN+43kjgmN/Y4Ujh2OH44sji6OI44vjjqOQI45jjWOPY4x" +
+	"jkmOQY5CjlGOUo5KjnCOdo58jm+OdI6Fjo+OlI6QjpyOnox4jIKMioyFjJiMlGWbidaJ3onaidz/////ieWJ64nvij6LJpdTlumW85bvlwaXAZcIlw+XDpcqly2XMJc+n4Cfg5+Fn4afh5+In4mfip+Mnv6fC58NlrmWvJa9ls6W0ne/luCSjpKuksiTPpNqk8qTj5Q+" +
+	"lGucf5yCnIWchpyHnIh6I5yLnI6ckJyRnJKclJyVnJqcm5yenJ+coJyhnKKco5ylnKacp5yonKmcq5ytnK6csJyxnLKcs5y0nLWctpy3nLqcu5y8nL2cxJzFnMacx5zKnMv/////nMyczZzOnM+c0JzTnNSc1ZzXnNic2ZzcnN2c35zil3yXhZeRl5KXlJevl6uXo5ey" +
+	"l7SasZqwmreeWJq2mrqavJrBmsCaxZrCmsuazJrRm0WbQ5tHm0mbSJtNm1GY6JkNmS6ZVZlUmt+a4Zrmmu+a65r7mu2a+ZsImw+bE5sfmyOevZ6+fjuegp6Hnoiei56Sk9aenZ6fntue3J7dnuCe357inume557lnuqe758inyyfL585nzefPZ8+n0Q="
@@ -0,0 +1,128 @@
+package go_qr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeHanzi(t *testing.T) {
+	// 啊 is GB 2312 0xB0A1, the first level-1 hanzi: (0xB0-0xA6)*0x60 + 0.
+	seg, err := MakeHanzi("啊")
+	assert.NoError(t, err)
+	assert.Equal(t, Hanzi, seg.mode)
+	assert.Equal(t, 1, seg.numChars)
+	assert.Equal(t, 13, seg.data.len())
+	assert.Equal(t, 0x3C0, bitsValue(seg.data, 0, 13))
+
+	// The ideographic comma is 0xA1A2, in the symbol rows.
+	seg, err = MakeHanzi("、")
+	assert.NoError(t, err)
+	assert.Equal(t, 0x001, bitsValue(seg.data, 0, 13))
+
+	_, err = MakeHanzi("中a")
+	assert.ErrorIs(t, err, ErrUnencodableChar)
+}
+
+func bitsValue(bb *BitBuffer, start, n int) int {
+	v := 0
+	for i := start; i < start+n; i++ {
+		v <<= 1
+		if bb.getBit(i) {
+			v |= 1
+		}
+	}
+	return v
+}
+
+func TestHanziRoundTrip(t *testing.T) {
+	const text = "这是简体中文二维码测试"
+	seg, err := MakeHanzi(text)
+	assert.NoError(t, err)
+	qr, err := EncodeStandardSegments([]*QrSegment{seg}, Medium)
+	assert.NoError(t, err)
+
+	res := decodeSymbol(t, qr)
+	assert.Equal(t, text, res.Text)
+	assert.Equal(t, 0xD, res.Segments[0].Mode)
+	assert.Equal(t, 11, res.Segments[0].NumChars)
+}
+
+func TestOptimalSegmentsUseHanzi(t *testing.T) {
+	// 这 and 们 are simplified forms outside Shift_JIS; 123456 stays numeric.
+	const text = "这些人们123456"
+	segs, err := MakeSegmentsOptimallyHanzi(text, Low, 1, 40)
+	assert.NoError(t, err)
+	assert.Len(t, segs, 2)
+	assert.Equal(t, Hanzi, segs[0].mode)
+	assert.Equal(t, Numeric, segs[1].mode)
+	// Mode, subset and 8-bit count header, 13 bits per character.
+	assert.Equal(t, 4+4+8+4*13+4+10+20, getTotalBits(segs, 1))
+
+	bytesSegs, err := MakeSegments(text)
+	assert.NoError(t, err)
+	assert.Less(t, getTotalBits(segs, 1), getTotalBits(bytesSegs, 1))
+
+	// Characters in both sets keep the cheaper Kanji header.
+	segs, err = MakeSegmentsOptimallyHanzi("中文", Low, 1, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, Kanji, segs[0].mode)
+
+	qr, err := Encode(text, WithSegmentation(SegmentationOptimalHanzi))
+	assert.NoError(t, err)
+	assert.Equal(t, text, decodeSymbol(t, qr).Text)
+
+	plan, err := Plan(text, Low, WithSegmentation(SegmentationOptimalHanzi))
+	assert.NoError(t, err)
+	assert.Equal(t, Hanzi, plan.Segments[0].Mode)
+}
+
+func TestOptimalSegmentsHanziOptIn(t *testing.T) {
+	// Without the opt-in Hanzi mode is never chosen: Latin-1 text whose
+	// UTF-8 bytes happen to pair into GB 2312 codes stays in byte mode, and
+	// so do pinyin and simplified Chinese.
+	for _, text := range []string{strings.Repeat("é", 20), "nǐ hǎo, zhōngguó", "这些人们123456"} {
+		segs, err := MakeSegmentsOptimally(text, Low, 1, 40)
+		assert.NoError(t, err)
+		for _, seg := range segs {
+			assert.NotEqual(t, Hanzi, seg.mode, text)
+		}
+		qr, err := Encode(text, WithSegmentation(SegmentationOptimal))
+		assert.NoError(t, err)
+		for _, seg := range decodeSymbol(t, qr).Segments {
+			assert.NotEqual(t, 0xD, seg.Mode, text)
+		}
+	}
+
+	segs, err := MakeSegmentsOptimally(strings.Repeat("é", 20), Low, 1, 40)
+	assert.NoError(t, err)
+	if assert.Len(t, segs, 1) {
+		assert.Equal(t, Byte, segs[0].mode)
+	}
+	segs, _, err = MakeSegmentsAutoCharset(strings.Repeat("é", 20), Low, 1, 40, CharsetUTF8, CharsetISO8859_1)
+	assert.NoError(t, err)
+	for _, seg := range segs {
+		assert.NotEqual(t, Hanzi, seg.mode)
+	}
+}
+
+func TestParseHanziUnsupportedSubset(t *testing.T) {
+	// Mode 1101, subset 0010, then nothing.
+	_, _, _, err := parseBitstream([]byte{0xD2, 0x00}, 1)
+	assert.ErrorIs(t, err, ErrUnsupportedSymbol)
+}
+
+func TestOptimalSegmentsHanziEmptyText(t *testing.T) {
+	segs, err := MakeSegmentsOptimallyHanzi("", Low, 1, 40)
+	assert.NoError(t, err)
+	assert.Empty(t, segs)
+
+	qr, err := Encode("", WithSegmentation(SegmentationOptimalHanzi))
+	assert.NoError(t, err)
+	assert.Equal(t, "", decodeSymbol(t, qr).Text)
+
+	qr, _, err = EncodeForLogo("", 0.1, LogoSquare, WithSegmentation(SegmentationOptimalHanzi))
+	assert.NoError(t, err)
+	assert.NotNil(t, qr)
+}
@@ -0,0 +1,121 @@
+package go_qr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// HiddenTagSize is the length of the truncated HMAC-SHA256 tag that
+// WithHiddenPayloadHMAC appends to the payload.
+const HiddenTagSize = 8
+
+// hiddenPayload is the caller's padding content; framed payloads carry a
+// length byte and an HMAC tag under key.
+type hiddenPayload struct {
+	data, key []byte
+	framed    bool
+}
+
+// WithHiddenPayload writes payload into the pad codewords after the
+// terminator, where scanners see only padding and ignore it. Bytes of the
+// padding the payload does not use keep the standard 0xEC/0x11 pattern, so
+// the reader gets them back too; frame the payload if its length matters.
+// The version is chosen so that the message and payload both fit.
+func WithHiddenPayload(payload []byte) EncodeOption {
+	return func(c *encodeConfig) {
+		c.hidden = &hiddenPayload{data: payload}
+	}
+}
+
+// WithHiddenPayloadHMAC is WithHiddenPayload with framing: a length byte, the
+// payload (at most 255 bytes) and the first HiddenTagSize bytes of
+// HMAC-SHA256 under key over the length byte and payload. Read it back with
+// OpenHiddenPayload. Encoding fails with ErrInvalidArgument if key is empty.
+func WithHiddenPayloadHMAC(payload, key []byte) EncodeOption {
+	return func(c *encodeConfig) {
+		c.hidden = &hiddenPayload{data: payload, key: key, framed: true}
+	}
+}
+
+// OpenHiddenPayload verifies a payload framed by WithHiddenPayloadHMAC at the
+// start of padding (DecodeResult.Padding) and returns it. It fails with
+// ErrAuthFailed when the frame is truncated or the tag does not match.
+func OpenHiddenPayload(padding, key []byte) ([]byte, error) {
+	if len(padding) < 1 || len(padding) < 1+int(padding[0])+HiddenTagSize {
+		return nil, fmt.Errorf("%w: hidden payload frame truncated", ErrAuthFailed)
+	}
+	n := 1 + int(padding[0])
+	if !hmac.Equal(padding[n:n+HiddenTagSize], hiddenTag(padding[:n], key)) {
+		return nil, fmt.Errorf("%w: hidden payload tag mismatch", ErrAuthFailed)
+	}
+	return append([]byte(nil), padding[1:n]...), nil
+}
+
+// hiddenTag returns the truncated HMAC-SHA256 of msg under key.
+func hiddenTag(msg, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return mac.Sum(nil)[:HiddenTagSize]
+}
+
+// bytes returns the bytes to place in the padding.
+func (h *hiddenPayload) bytes() ([]byte, error) {
+	if !h.framed {
+		return h.data, nil
+	}
+	if len(h.key) == 0 {
+		return nil, fmt.Errorf("%w: empty hidden payload HMAC key", ErrInvalidArgument)
+	}
+	if len(h.data) > 255 {
+		return nil, fmt.Errorf("%w: framed hidden payload is %d bytes, max 255", ErrInvalidArgument, len(h.data))
+	}
+	frame := append([]byte{byte(len(h.data))}, h.data...)
+	return append(frame, hiddenTag(frame, h.key)...), nil
+}
+
+// encodeHidden encodes segs in the smallest version whose pad codewords,
+// after the terminator and bit padding, hold the hidden payload.
+// The options must have passed validate.
+func (c *encodeConfig) encodeHidden(segs []*QrSegment) (*QrCode, error) {
+	sel := c.maskSelector
+	if sel == nil && c.mask != -1 {
+		sel = fixedMask(c.mask)
+	}
+	hidden, err := c.hidden.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Codewords needed for the message, a full terminator and the payload.
+	needed := func(version int) int {
+		used := getTotalBits(segs, version)
+		if used == -1 {
+			return -1
+		}
+		return (used+4+7)/8 + len(hidden)
+	}
+	for version := c.minVersion; version <= c.maxVersion; version++ {
+		n := needed(version)
+		if n == -1 || n > getNumDataCodewords(version, c.ecl) {
+			continue
+		}
+		ecl := c.ecl
+		if c.boostEcl {
+			for _, e := range []Ecc{Medium, Quartile, High} {
+				if e > ecl && n <= getNumDataCodewords(version, e) {
+					ecl = e
+				}
+			}
+		}
+
+		data, terminated, err := makeDataCodewords(segs, version, ecl)
+		if err != nil {
+			return nil, err
+		}
+		copy(data[(terminated+7)/8:], hidden)
+		return newQrCode(version, ecl, data, sel)
+	}
+	return nil, fmt.Errorf("%w: message and %d-byte hidden payload exceed versions %d-%d at ECC %d",
+		ErrDataTooLong, len(hidden), c.minVersion, c.maxVersion, c.ecl)
+}
@@ -0,0 +1,87 @@
+package go_qr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeSymbol(t *testing.T, qr *QrCode) *DecodeResult {
+	img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+	assert.NoError(t, err)
+	res, err := DecodeDetailed(img)
+	assert.NoError(t, err)
+	return res
+}
+
+func TestDecodePadding(t *testing.T) {
+	qr, err := Encode("HELLO", WithBoostEcl(false))
+	assert.NoError(t, err)
+	res := decodeSymbol(t, qr)
+	assert.Equal(t, "HELLO", res.Text)
+	// 1-L holds 19 data codewords; "HELLO" plus its terminator fills 6.
+	assert.Equal(t, bytes.Repeat([]byte{0xEC, 0x11}, 7)[:13], res.Padding)
+}
+
+func TestHiddenPayload(t *testing.T) {
+	secret := []byte("batch-42")
+	qr, err := Encode("HELLO", WithHiddenPayload(secret), WithBoostEcl(false))
+	assert.NoError(t, err)
+	res := decodeSymbol(t, qr)
+	assert.Equal(t, "HELLO", res.Text)
+	assert.True(t, bytes.HasPrefix(res.Padding, secret))
+	// The rest of the padding keeps the standard pattern.
+	assert.Equal(t, []byte{0xEC, 0x11}, res.Padding[len(secret):len(secret)+2])
+
+	// The version grows to fit the payload.
+	qr, err = Encode("HELLO", WithHiddenPayload(bytes.Repeat([]byte{0xAA}, 30)))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, qr.version)
+	res = decodeSymbol(t, qr)
+	assert.Equal(t, bytes.Repeat([]byte{0xAA}, 30), res.Padding[:30])
+
+	_, err = Encode("HELLO", WithHiddenPayload(make([]byte, 20)), WithMaxVersion(1))
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestHiddenPayloadHMAC(t *testing.T) {
+	key := []byte("label-key")
+	qr, err := Encode("https://example.com/p/1", WithHiddenPayloadHMAC([]byte("SN 0001"), key), WithMask(2), WithEcc(Medium))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, qr.Mask())
+	assert.GreaterOrEqual(t, qr.errorCorrectionLevel, Medium)
+
+	res := decodeSymbol(t, qr)
+	assert.Equal(t, "https://example.com/p/1", res.Text)
+	got, err := OpenHiddenPayload(res.Padding, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("SN 0001"), got)
+
+	_, err = OpenHiddenPayload(res.Padding, []byte("other-key"))
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	tampered := append([]byte(nil), res.Padding...)
+	tampered[3] ^= 1
+	_, err = OpenHiddenPayload(tampered, key)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+
+	// A plain symbol's padding is not a valid frame.
+	plain, err := Encode("https://example.com/p/1")
+	assert.NoError(t, err)
+	_, err = OpenHiddenPayload(decodeSymbol(t, plain).Padding, key)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	_, err = OpenHiddenPayload(nil, key)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+
+	_, err = Encode("x", WithHiddenPayloadHMAC(make([]byte, 256), key))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	// A missing key never falls back to an unframed payload.
+	for _, k := range [][]byte{nil, {}} {
+		_, err = Encode("x", WithHiddenPayloadHMAC([]byte("secret"), k))
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	}
+	_, err = Encode("x", WithHiddenPayload([]byte{1}), WithMask(8))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = Encode("x", WithHiddenPayload([]byte{1}), WithMinVersion(2), WithMaxVersion(1))
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
@@ -1,29 +1,28 @@
 package go_qr
 
-// getNumRawDataModules returns the number of raw data modules (bits available
-// for data + ECC codewords, divided by 8 elsewhere) for a QR code version.
-func getNumRawDataModules(ver int) int {
-	// Total modules in the size×size grid.
-	size := ver*4 + 17
-	res := size * size
+// getRMQRNumRawDataModules is the rMQR counterpart of qrspec.RawDataModules.
+func getRMQRNumRawDataModules(ver int) int {
+	height, width := rmqrDimensions[ver][0], rmqrDimensions[ver][1]
+	res := width * height
 
-	// Subtract the three 8×8 finder-pattern regions (incl. separators).
-	res -= 8 * 8 * 3
+	// Subtract the timing patterns and corner patterns that run along all
+	// four edges.
+	res -= width*2 + (height-2)*2
 
-	// Subtract the two timing patterns (15 modules each) and the dark module.
-	res -= 15*2 + 1
+	// Subtract the finder pattern with its separator (clipped by the bottom
+	// edge in R7), the finder sub-pattern and both format information areas.
+	res -= 7 * min(height-2, 7)
+	res -= 4 * 4
+	res -= 18 * 2
 
-	// Subtract the timing-pattern border modules.
-	res -= (size - 16) * 2
-
-	// Alignment patterns and version info exist from version 2 / 7 up.
-	if ver >= 2 {
-		numAlign := ver/7 + 2
-		res -= (numAlign - 1) * (numAlign - 1) * 25
-		res -= (numAlign - 2) * 2 * 20
-		if ver >= 7 {
-			res -= 6 * 3 * 2
-		}
+	// Subtract the corner pattern modules that reach inside the edges.
+	res--
+	if height >= 11 {
+		res--
 	}
+
+	// Each alignment column holds two 3×3 patterns (one row of each lies on
+	// an edge) joined by a vertical timing pattern.
+	res -= len(rmqrAlignmentPositions(width)) * (6*2 + height - 6)
 	return res
 }
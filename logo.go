@@ -6,14 +6,26 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
 	"image/draw"
 	"image/png"
+
+	"github.com/piglig/go-qr/qrspec"
+)
+
+// LogoShape is the outline of a logo and of its light padding.
+type LogoShape int
+
+const (
+	LogoSquare LogoShape = iota // square box, the default
+	LogoCircle                  // disc inscribed in the square box
 )
 
 // logoConfig holds the configuration for embedding a logo in the center of a QR code.
 type logoConfig struct {
 	img       image.Image
 	sizeRatio float64
+	shape     LogoShape
 }
 
 // WithLogo embeds the given image in the center of the QR code.
@@ -24,43 +36,30 @@ type logoConfig struct {
 // QR modules to keep finder patterns readable.
 //
 // The logo occludes a portion of the QR modules and relies on error correction
-// to remain scannable. Higher error correction levels tolerate larger logos;
-// rendering will fail if the occluded area exceeds what the chosen ECC can
-// realistically recover.
+// to remain scannable. Rendering fails if the occluded codewords exceed what
+// the symbol's ECC can recover; see QrCode.LogoDamage.
 func WithLogo(img image.Image, sizeRatio float64) Option {
+	return WithShapedLogo(img, sizeRatio, LogoSquare)
+}
+
+// WithShapedLogo is WithLogo with an explicit outline. A LogoCircle logo is
+// clipped to a disc and occludes fewer modules than a square one of the same
+// sizeRatio.
+func WithShapedLogo(img image.Image, sizeRatio float64, shape LogoShape) Option {
 	return func(q *QrCodeImgConfig) {
-		q.logo = &logoConfig{img: img, sizeRatio: sizeRatio}
-	}
-}
-
-// eccRecoveryBudget returns the fraction of modules that can safely be occluded
-// for the given ECC level. Values are conservative: the spec defines recovery
-// capacity per codeword, but in practice finder-pattern position and masking
-// make the usable budget smaller.
-func eccRecoveryBudget(ecl Ecc) float64 {
-	switch ecl {
-	case Low:
-		return 0.05
-	case Medium:
-		return 0.12
-	case Quartile:
-		return 0.20
-	case High:
-		return 0.25
-	default:
-		return 0.05
+		q.logo = &logoConfig{img: img, sizeRatio: sizeRatio, shape: shape}
 	}
 }
 
-// logoRect computes the logo's occluded rectangle in image-pixel coordinates,
-// including the 1-module white padding. It also returns the occluded area as
-// a fraction of the QR module area (excluding the border).
-func (l *logoConfig) logoRect(qrSize, scale, border int) (image.Rectangle, float64, error) {
+// boxModules returns the side, in modules, of the logo box including the
+// 1-module padding. It has the parity of qrSize, so the box is centered on
+// module boundaries.
+func (l *logoConfig) boxModules(qrSize int) (int, error) {
 	if l.sizeRatio <= 0 || l.sizeRatio >= 1 {
-		return image.Rectangle{}, 0, fmt.Errorf("logo sizeRatio must be in (0, 1), got %v", l.sizeRatio)
+		return 0, fmt.Errorf("logo sizeRatio must be in (0, 1), got %v", l.sizeRatio)
 	}
-	if l.img == nil {
-		return image.Rectangle{}, 0, errors.New("logo image is nil")
+	if l.shape != LogoSquare && l.shape != LogoCircle {
+		return 0, fmt.Errorf("%w: unknown logo shape %d", ErrInvalidConfig, int(l.shape))
 	}
 
 	// Logo side in modules, rounded down to an even integer so it centers cleanly.
@@ -79,7 +78,21 @@ func (l *logoConfig) logoRect(qrSize, scale, border int) (image.Rectangle, float
 	const paddingModules = 1
 	boxModules := logoModules + 2*paddingModules
 	if boxModules >= qrSize {
-		return image.Rectangle{}, 0, fmt.Errorf("logo too large: covers %d of %d modules", boxModules, qrSize)
+		return 0, fmt.Errorf("logo too large: covers %d of %d modules", boxModules, qrSize)
+	}
+	return boxModules, nil
+}
+
+// logoRect computes the logo's occluded rectangle in image-pixel coordinates,
+// including the 1-module white padding. It also returns the occluded area as
+// a fraction of the QR module area (excluding the border).
+func (l *logoConfig) logoRect(qrSize, scale, border int) (image.Rectangle, float64, error) {
+	boxModules, err := l.boxModules(qrSize)
+	if err != nil {
+		return image.Rectangle{}, 0, err
+	}
+	if l.img == nil {
+		return image.Rectangle{}, 0, errors.New("logo image is nil")
 	}
 
 	// Center of the module area in image pixels. border is measured in modules.
@@ -91,21 +104,134 @@ func (l *logoConfig) logoRect(qrSize, scale, border int) (image.Rectangle, float
 	return rect, occludedRatio, nil
 }
 
-// validate checks that the logo configuration is compatible with the QR code's
-// error correction level.
-func (l *logoConfig) validate(q *QrCode, scale, border int) error {
-	_, ratio, err := l.logoRect(q.Size(), scale, border)
+// logoCover tells what part of the logo covers a module.
+type logoCover int
+
+const (
+	coverNone    logoCover = iota
+	coverPadding           // only the light padding
+	coverImage             // the logo image itself
+)
+
+// cover reports what covers module (x, y) of a qrSize symbol whose logo box
+// is boxModules wide. Geometry is in module units, so it holds at any scale.
+func (l *logoConfig) cover(qrSize, boxModules, x, y int) logoCover {
+	if l.shape == LogoCircle {
+		c := float64(qrSize) / 2
+		r := float64(boxModules) / 2
+		// Squared distance from the center to the nearest point of the module.
+		dx := max(c-float64(x+1), float64(x)-c, 0)
+		dy := max(c-float64(y+1), float64(y)-c, 0)
+		d2 := dx*dx + dy*dy
+		switch {
+		case d2 < (r-1)*(r-1):
+			return coverImage
+		case d2 < r*r:
+			return coverPadding
+		}
+		return coverNone
+	}
+
+	lo := (qrSize - boxModules) / 2
+	hi := lo + boxModules
+	switch {
+	case x < lo || x >= hi || y < lo || y >= hi:
+		return coverNone
+	case x == lo || x == hi-1 || y == lo || y == hi-1:
+		return coverPadding
+	}
+	return coverImage
+}
+
+// validate checks that the logo leaves every Reed-Solomon block of the symbol
+// correctable.
+func (l *logoConfig) validate(q *QrCode) error {
+	d, err := l.damage(q)
 	if err != nil {
 		return err
 	}
-	budget := eccRecoveryBudget(q.errorCorrectionLevel)
-	if ratio > budget {
-		return fmt.Errorf("logo occludes %.1f%% of QR modules, exceeds ECC %v budget of %.1f%% (use a smaller sizeRatio or a higher ECC)",
-			ratio*100, q.errorCorrectionLevel, budget*100)
+	if d.Margin < 0 {
+		worst := d.Blocks[d.WorstBlock]
+		return &LogoBudgetError{Block: d.WorstBlock, Coverage: worst.Damaged, Budget: worst.Budget, Ecc: q.errorCorrectionLevel}
 	}
 	return nil
 }
 
+// LogoDamage is the codeword-level impact of a logo on a symbol. A codeword
+// counts as damaged when the logo image touches any of its modules, or the
+// light padding around it covers one of its dark modules; function patterns
+// under the logo are not counted.
+type LogoDamage struct {
+	Blocks     []BlockDamage // one entry per Reed-Solomon block
+	WorstBlock int           // index of the block with the smallest margin
+	Margin     int           // Blocks[WorstBlock].Margin(); negative means unreadable
+}
+
+// BlockDamage is the logo damage to one Reed-Solomon block.
+type BlockDamage struct {
+	Damaged int // codewords touched by the logo
+	Budget  int // correctable codewords: floor(ECC codewords / 2)
+}
+
+// Margin returns how many more codewords of the block could be damaged.
+func (b BlockDamage) Margin() int {
+	return b.Budget - b.Damaged
+}
+
+// LogoDamage maps the logo configured with WithLogo onto the codewords and
+// Reed-Solomon blocks of q. Rendering with config fails when Margin is
+// negative.
+func (q *QrCode) LogoDamage(config *QrCodeImgConfig) (*LogoDamage, error) {
+	if err := config.valid(); err != nil {
+		return nil, err
+	}
+	if config.logo == nil {
+		return nil, fmt.Errorf("%w: no logo configured", ErrInvalidConfig)
+	}
+	return config.logo.damage(q)
+}
+
+// damage counts the distinct codewords the logo damages in each block.
+func (l *logoConfig) damage(q *QrCode) (*LogoDamage, error) {
+	if q.Width() != q.Height() {
+		return nil, fmt.Errorf("%w: logos require a square symbol", ErrInvalidConfig)
+	}
+	boxModules, err := l.boxModules(q.Size())
+	if err != nil {
+		return nil, err
+	}
+	lo := (q.Size() - boxModules) / 2
+
+	structure := q.blockStructure()
+	d := &LogoDamage{Blocks: make([]BlockDamage, structure.NumBlocks)}
+	for i := range d.Blocks {
+		d.Blocks[i].Budget = structure.ECCodewordsPerBlock / 2
+	}
+	layout := q.layout()
+	damaged := make(map[int]bool)
+	for y := lo; y < lo+boxModules; y++ {
+		for x := lo; x < lo+boxModules; x++ {
+			m := layout[y][x]
+			if m.Kind != ModuleData && m.Kind != ModuleECC || damaged[m.Codeword] {
+				continue
+			}
+			// Light modules under the light padding read back unchanged.
+			if c := l.cover(q.Size(), boxModules, x, y); c == coverImage || c == coverPadding && q.modules[y][x] {
+				damaged[m.Codeword] = true
+				d.Blocks[structure.CodewordBlock(m.Codeword)].Damaged++
+			}
+		}
+	}
+
+	for i, b := range d.Blocks {
+		if b.Margin() < d.Blocks[d.WorstBlock].Margin() {
+			d.WorstBlock = i
+		}
+	}
+	d.Margin = d.Blocks[d.WorstBlock].Margin()
+	return d, nil
+}
+
 // overlayOnImage composites the logo (with white padding) onto the given RGBA image.
 func (l *logoConfig) overlayOnImage(dst *image.RGBA, qrSize, scale, border int) error {
 	rect, _, err := l.logoRect(qrSize, scale, border)
@@ -113,13 +239,37 @@ func (l *logoConfig) overlayOnImage(dst *image.RGBA, qrSize, scale, border int)
 		return err
 	}
 
-	// White padding box.
-	draw.Draw(dst, rect, &image.Uniform{C: image.White}, image.Point{}, draw.Src)
-
 	// Inset for the actual logo (strip 1-module padding on each side).
 	inset := scale
 	logoRect := image.Rect(rect.Min.X+inset, rect.Min.Y+inset, rect.Max.X-inset, rect.Max.Y-inset)
 
+	if l.shape == LogoCircle {
+		// The same disc as cover, in pixels; a pixel belongs to it when its
+		// center does.
+		boxModules, _ := l.boxModules(qrSize)
+		c := float64(border*scale) + float64(qrSize*scale)/2
+		r := float64(boxModules*scale) / 2
+		inDisc := func(x, y int, r float64) bool {
+			dx, dy := float64(x)+0.5-c, float64(y)+0.5-c
+			return dx*dx+dy*dy < r*r
+		}
+		lo := (border + (qrSize-boxModules)/2) * scale
+		for y := lo; y < lo+boxModules*scale; y++ {
+			for x := lo; x < lo+boxModules*scale; x++ {
+				if inDisc(x, y, r) {
+					dst.Set(x, y, color.White)
+				}
+			}
+		}
+		drawScaledMasked(dst, logoRect, l.img, func(x, y int) bool {
+			return inDisc(x, y, r-float64(scale))
+		})
+		return nil
+	}
+
+	// White padding box.
+	draw.Draw(dst, rect, &image.Uniform{C: image.White}, image.Point{}, draw.Src)
+
 	// Scale the source image into logoRect using nearest-neighbor. A high-quality
 	// scaler would pull in golang.org/x/image; nearest is sufficient since logos
 	// are typically pre-sized by the caller.
@@ -129,6 +279,12 @@ func (l *logoConfig) overlayOnImage(dst *image.RGBA, qrSize, scale, border int)
 
 // drawScaled performs nearest-neighbor scaling of src into dst's dstRect.
 func drawScaled(dst *image.RGBA, dstRect image.Rectangle, src image.Image) {
+	drawScaledMasked(dst, dstRect, src, nil)
+}
+
+// drawScaledMasked is drawScaled restricted to the destination pixels for
+// which inside reports true; a nil inside draws every pixel.
+func drawScaledMasked(dst *image.RGBA, dstRect image.Rectangle, src image.Image, inside func(x, y int) bool) {
 	sb := src.Bounds()
 	dw := dstRect.Dx()
 	dh := dstRect.Dy()
@@ -139,6 +295,9 @@ func drawScaled(dst *image.RGBA, dstRect image.Rectangle, src image.Image) {
 		sy := sb.Min.Y + y*sb.Dy()/dh
 		for x := 0; x < dw; x++ {
 			sx := sb.Min.X + x*sb.Dx()/dw
+			if inside != nil && !inside(dstRect.Min.X+x, dstRect.Min.Y+y) {
+				continue
+			}
 			dst.Set(dstRect.Min.X+x, dstRect.Min.Y+y, src.At(sx, sy))
 		}
 	}
@@ -164,6 +323,20 @@ func (l *logoConfig) svgEmbed(qrSize, scale, border int) (string, error) {
 	logoW := rect.Dx() - 2*inset
 	logoH := rect.Dy() - 2*inset
 
+	if l.shape == LogoCircle {
+		boxModules, _ := l.boxModules(qrSize)
+		c := float64(border*scale) + float64(qrSize*scale)/2
+		r := float64(boxModules*scale) / 2
+		return fmt.Sprintf(
+			"\t<clipPath id=\"qr-logo-clip\"><circle cx=\"%g\" cy=\"%g\" r=\"%g\"/></clipPath>\n"+
+				"\t<circle cx=\"%g\" cy=\"%g\" r=\"%g\" fill=\"#FFFFFF\"/>\n"+
+				"\t<image x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" clip-path=\"url(#qr-logo-clip)\" href=\"data:image/png;base64,%s\"/>\n",
+			c, c, r-float64(scale),
+			c, c, r,
+			logoX, logoY, logoW, logoH, encoded,
+		), nil
+	}
+
 	return fmt.Sprintf(
 		"\t<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#FFFFFF\"/>\n"+
 			"\t<image x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" href=\"data:image/png;base64,%s\"/>\n",
@@ -171,3 +344,28 @@ func (l *logoConfig) svgEmbed(qrSize, scale, border int) (string, error) {
 		logoX, logoY, logoW, logoH, encoded,
 	), nil
 }
+
+// blockStructure returns the Reed-Solomon block structure of the symbol.
+func (q *QrCode) blockStructure() qrspec.Blocks {
+	var total, numBlocks, eccLen int
+	switch q.symbol {
+	case SymbolMicroQR:
+		// Micro QR always uses a single block.
+		eccLen = microEccCodewords[q.version][q.errorCorrectionLevel]
+		total = (microDataBits[q.version][q.errorCorrectionLevel]+7)/8 + eccLen
+		numBlocks = 1
+	case SymbolRMQR:
+		row := rmqrEccRow(q.errorCorrectionLevel)
+		eccLen = int(rmqrEccCodeWordsPerBlock[row][q.version])
+		numBlocks = int(rmqrNumErrorCorrectionBlocks[row][q.version])
+		total = getRMQRNumRawDataModules(q.version) / 8
+	default:
+		return qrspec.BlockStructure(q.version, qrspec.Level(q.errorCorrectionLevel))
+	}
+	return qrspec.Blocks{
+		NumBlocks:               numBlocks,
+		NumShortBlocks:          numBlocks - total%numBlocks,
+		ECCodewordsPerBlock:     eccLen,
+		ShortBlockDataCodewords: total/numBlocks - eccLen,
+	}
+}
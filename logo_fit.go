@@ -0,0 +1,125 @@
+package go_qr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piglig/go-qr/qrspec"
+)
+
+// EncodeForLogo encodes text so that a centered logo of the given sizeRatio
+// and shape stays within the error correction capacity of every
+// Reed-Solomon block, and returns the symbol together with the damage the
+// logo will do to it.
+//
+// The search walks versions from the smallest up. At each version it tries
+// every ECC level from High down to the WithEcc level (Low by default) that
+// still holds the data, and every mask (or only the WithMask one). The first
+// version with a fitting candidate wins; among its candidates the largest
+// margin wins, then the higher ECC level, then the lower mask penalty.
+// WithBoostEcl and WithMaskSelector are ignored.
+//
+// Render the result with WithLogo or WithShapedLogo using the same sizeRatio
+// and shape. If no candidate fits, the error wraps ErrDataTooLong when the
+// text does not fit at all and ErrInvalidConfig when only the logo does not.
+func EncodeForLogo(text string, sizeRatio float64, shape LogoShape, options ...EncodeOption) (*QrCode, *LogoDamage, error) {
+	c := &encodeConfig{
+		ecl:        Low,
+		minVersion: MinVersion,
+		maxVersion: MaxVersion,
+		mask:       -1,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	if err := c.validate(); err != nil {
+		return nil, nil, err
+	}
+	masks := []int{c.mask}
+	if c.mask == -1 {
+		masks = []int{0, 1, 2, 3, 4, 5, 6, 7}
+	}
+
+	logo := &logoConfig{sizeRatio: sizeRatio, shape: shape}
+	if _, err := logo.boxModules(qrspec.Size(c.maxVersion)); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	fitsData := false
+	var best *logoCandidate
+	for version := c.minVersion; version <= c.maxVersion && best == nil; version++ {
+		// Optimal segmentation depends on the version class.
+		vc := *c
+		vc.minVersion, vc.maxVersion = version, version
+		for ecl := High; ecl >= c.ecl; ecl-- {
+			vc.ecl = ecl
+			segs, err := vc.segments(text)
+			if errors.Is(err, ErrDataTooLong) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			if _, _, err := fitVersion(segs, ecl, version, version); err != nil {
+				continue
+			}
+			fitsData = true
+
+			for _, mask := range masks {
+				qr, err := encodeSegments(segs, ecl, version, version, fixedMask(mask), false)
+				if err != nil {
+					return nil, nil, err
+				}
+				d, err := logo.damage(qr)
+				if err != nil {
+					// The logo box does not fit inside this symbol.
+					break
+				}
+				if d.Margin < 0 {
+					continue
+				}
+				penalty := newBuilder(version, ecl).getPenaltyBreakdown(qr.modules).Total()
+				cand := &logoCandidate{qr: qr, damage: d, penalty: penalty}
+				if best == nil || cand.better(best) {
+					best = cand
+				}
+			}
+		}
+	}
+
+	if best == nil {
+		if !fitsData {
+			// Report the shortfall at the largest version and lowest level.
+			vc := *c
+			vc.minVersion = c.maxVersion
+			segs, err := vc.segments(text)
+			if err == nil {
+				_, _, err = fitVersion(segs, c.ecl, c.maxVersion, c.maxVersion)
+			}
+			if err == nil {
+				err = fmt.Errorf("%w: text does not fit versions %d-%d at ECC %d", ErrDataTooLong, c.minVersion, c.maxVersion, c.ecl)
+			}
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%w: logo sizeRatio %v exceeds ECC capacity of every candidate in versions %d-%d", ErrInvalidConfig, sizeRatio, c.minVersion, c.maxVersion)
+	}
+	return best.qr, best.damage, nil
+}
+
+// logoCandidate is a symbol considered by EncodeForLogo.
+type logoCandidate struct {
+	qr      *QrCode
+	damage  *LogoDamage
+	penalty int // ISO/IEC 18004 mask penalty score
+}
+
+// better reports whether c beats o: larger margin, then higher ECC level,
+// then lower mask penalty.
+func (c *logoCandidate) better(o *logoCandidate) bool {
+	if c.damage.Margin != o.damage.Margin {
+		return c.damage.Margin > o.damage.Margin
+	}
+	if c.qr.errorCorrectionLevel != o.qr.errorCorrectionLevel {
+		return c.qr.errorCorrectionLevel > o.qr.errorCorrectionLevel
+	}
+	return c.penalty < o.penalty
+}
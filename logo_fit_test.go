@@ -0,0 +1,116 @@
+package go_qr
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeForLogo(t *testing.T) {
+	text := "https://example.com/logo"
+	qr, d, err := EncodeForLogo(text, 0.3, LogoSquare)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, d.Margin, 0)
+
+	// The reported damage is what rendering checks.
+	logo := makeTestLogo(40, 40, color.Black)
+	cfg := NewQrCodeImgConfig(4, 4, WithLogo(logo, 0.3))
+	got, err := qr.LogoDamage(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, d, got)
+
+	img, err := qr.ToImage(cfg)
+	assert.NoError(t, err)
+	decoded, err := Decode(img)
+	assert.NoError(t, err)
+	assert.Equal(t, text, decoded)
+
+	// A plain encode at the same version cannot carry the logo.
+	plain, err := Encode(text)
+	assert.NoError(t, err)
+	if plain.version == qr.version {
+		_, err = plain.ToPNGBytes(cfg)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	}
+}
+
+func TestEncodeForLogoSearch(t *testing.T) {
+	text := "HELLO WORLD"
+	qr, d, err := EncodeForLogo(text, 0.3, LogoSquare)
+	assert.NoError(t, err)
+
+	// No smaller version fits, and no candidate of this version beats the
+	// chosen one.
+	for v := MinVersion; v < qr.version; v++ {
+		_, _, err := EncodeForLogo(text, 0.3, LogoSquare, WithMinVersion(v), WithMaxVersion(v))
+		assert.ErrorIs(t, err, ErrInvalidConfig, "version %d", v)
+	}
+	logo := &logoConfig{sizeRatio: 0.3}
+	for _, ecl := range []Ecc{Low, Medium, Quartile, High} {
+		for mask := 0; mask < 8; mask++ {
+			other, err := EncodeSegments(mustSegments(t, text), ecl, qr.version, qr.version, mask, false)
+			if err != nil {
+				continue
+			}
+			od, err := logo.damage(other)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, od.Margin, d.Margin, "ecc %d mask %d", ecl, mask)
+		}
+	}
+
+	// A fixed mask and a minimum ECC level restrict the search.
+	qr, _, err = EncodeForLogo(text, 0.3, LogoSquare, WithMask(5), WithEcc(Quartile))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, qr.Mask())
+	assert.GreaterOrEqual(t, qr.errorCorrectionLevel, Quartile)
+}
+
+func TestEncodeForLogoCircle(t *testing.T) {
+	text := "https://example.com/circle"
+	square, _, err := EncodeForLogo(text, 0.3, LogoSquare, WithMinVersion(5), WithMaxVersion(5), WithEcc(High))
+	assert.NoError(t, err)
+	sd, err := (&logoConfig{sizeRatio: 0.3}).damage(square)
+	assert.NoError(t, err)
+	cd, err := (&logoConfig{sizeRatio: 0.3, shape: LogoCircle}).damage(square)
+	assert.NoError(t, err)
+	// The disc is inscribed in the square box.
+	assert.GreaterOrEqual(t, cd.Margin, sd.Margin)
+
+	qr, d, err := EncodeForLogo(text, 0.35, LogoCircle)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, d.Margin, 0)
+	logo := makeTestLogo(40, 40, color.RGBA{R: 200, A: 255})
+	img, err := qr.ToImage(NewQrCodeImgConfig(5, 4, WithShapedLogo(logo, 0.35, LogoCircle)))
+	assert.NoError(t, err)
+	decoded, err := Decode(img)
+	assert.NoError(t, err)
+	assert.Equal(t, text, decoded)
+
+	svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(5, 4, WithShapedLogo(logo, 0.35, LogoCircle)))
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), "<clipPath id=\"qr-logo-clip\">")
+}
+
+func TestEncodeForLogoErrors(t *testing.T) {
+	_, _, err := EncodeForLogo("x", 0, LogoSquare)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, _, err = EncodeForLogo("x", 0.2, LogoShape(9))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, _, err = EncodeForLogo("x", 0.2, LogoSquare, WithMask(8))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, _, err = EncodeForLogo("x", 0.2, LogoSquare, WithMinVersion(3), WithMaxVersion(2))
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+
+	// Too much text for version 1, and too large a logo for any ECC.
+	_, _, err = EncodeForLogo("0123456789012345678901234567890123456789012345", 0.2, LogoSquare, WithMaxVersion(1))
+	assert.ErrorIs(t, err, ErrDataTooLong)
+	_, _, err = EncodeForLogo("x", 0.7, LogoSquare)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func mustSegments(t *testing.T, text string) []*QrSegment {
+	segs, err := MakeSegments(text)
+	assert.NoError(t, err)
+	return segs
+}
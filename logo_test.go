@@ -133,3 +133,84 @@ func TestWithLogo_ImageAPIIncludesLogo(t *testing.T) {
 	assert.Equal(t, uint32(0xc8c8), g)
 	assert.Equal(t, uint32(0x1414), b)
 }
+
+func TestLogoDamage(t *testing.T) {
+	qr, err := EncodeText("Hello, world!", High)
+	assert.NoError(t, err)
+	logo := makeTestLogo(40, 40, color.Black)
+
+	_, err = qr.LogoDamage(NewQrCodeImgConfig(10, 4))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	d, err := qr.LogoDamage(NewQrCodeImgConfig(10, 4, WithLogo(logo, 0.2)))
+	assert.NoError(t, err)
+	// Version 2-H is a single block of 28 ECC codewords.
+	assert.Equal(t, 2, qr.version)
+	assert.Len(t, d.Blocks, 1)
+	assert.Equal(t, 14, d.Blocks[0].Budget)
+	assert.Equal(t, d.Blocks[0].Margin(), d.Margin)
+	assert.GreaterOrEqual(t, d.Margin, 0)
+
+	// The damaged count is the number of distinct codewords under the logo
+	// image, plus those with a dark module under the padding ring.
+	codewords := map[int]bool{}
+	layout := qr.ModuleLayout()
+	box, err := (&logoConfig{sizeRatio: 0.2}).boxModules(qr.Size())
+	assert.NoError(t, err)
+	lo, hi := (qr.Size()-box)/2, (qr.Size()+box)/2
+	for y := lo; y < hi; y++ {
+		for x := lo; x < hi; x++ {
+			ring := x == lo || y == lo || x == hi-1 || y == hi-1
+			if layout[y][x].Codeword >= 0 && (!ring || qr.Module(x, y)) {
+				codewords[layout[y][x].Codeword] = true
+			}
+		}
+	}
+	assert.Equal(t, len(codewords), d.Blocks[0].Damaged)
+
+	// A logo within budget leaves the rendered symbol readable.
+	img, err := qr.ToImage(NewQrCodeImgConfig(10, 4, WithLogo(logo, 0.2)))
+	assert.NoError(t, err)
+	text, err := Decode(img)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, world!", text)
+}
+
+func TestLogoDamageMultipleBlocks(t *testing.T) {
+	qr, err := EncodeSegments([]*QrSegment{}, Quartile, 5, 5, 0, false)
+	assert.NoError(t, err)
+	logo := makeTestLogo(40, 40, color.Black)
+
+	d, err := qr.LogoDamage(NewQrCodeImgConfig(4, 2, WithLogo(logo, 0.15)))
+	assert.NoError(t, err)
+	assert.Len(t, d.Blocks, 4)
+	for i, b := range d.Blocks {
+		assert.Equal(t, 9, b.Budget)
+		assert.GreaterOrEqual(t, b.Margin(), d.Margin, "block %d", i)
+	}
+	assert.Equal(t, d.Blocks[d.WorstBlock].Margin(), d.Margin)
+
+	// Too large a logo overflows some block and fails rendering.
+	cfg := NewQrCodeImgConfig(4, 2, WithLogo(logo, 0.5))
+	d, err = qr.LogoDamage(cfg)
+	assert.NoError(t, err)
+	assert.Negative(t, d.Margin)
+	_, err = qr.ToPNGBytes(cfg)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "exceeds ECC")
+}
+
+func TestBlockStructureAllSymbologies(t *testing.T) {
+	micro, err := EncodeMicroSegments([]*QrSegment{}, Low, 1, 1, 0, false)
+	assert.NoError(t, err)
+	b := micro.blockStructure()
+	assert.Equal(t, 1, b.NumBlocks)
+	assert.Equal(t, 2, b.ECCodewordsPerBlock)
+	assert.Equal(t, 3, b.TotalDataCodewords())
+
+	rmqr, err := EncodeRMQRText("RMQR", Medium)
+	assert.NoError(t, err)
+	b = rmqr.blockStructure()
+	assert.Equal(t, getRMQRNumDataCodewords(rmqr.version, rmqr.errorCorrectionLevel), b.TotalDataCodewords())
+	assert.Equal(t, getRMQRNumRawDataModules(rmqr.version)/8, b.TotalDataCodewords()+b.NumBlocks*b.ECCodewordsPerBlock)
+}
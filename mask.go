@@ -39,7 +39,7 @@ func (q *builder) applyMask(msk int) error {
 		return fmt.Errorf("%w: mask %d out of range [0,7]", ErrInvalidArgument, msk)
 	}
 
-	for y := 0; y < q.size; y++ {
+	for y := 0; y < q.height; y++ {
 		for x := 0; x < q.size; x++ {
 			invert := maskInvert(msk, x, y)
 			q.modules[y][x] = q.modules[y][x] != (invert && !q.isFunction[y][x])
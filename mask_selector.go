@@ -0,0 +1,240 @@
+package go_qr
+
+import (
+	"fmt"
+	"image"
+)
+
+// MaskPenalty is the ISO/IEC 18004 penalty score of one mask pattern, broken
+// down by rule.
+type MaskPenalty struct {
+	Mask int
+	N1   int // runs of five or more same-colored modules in a row or column
+	N2   int // 2x2 blocks of one color
+	N3   int // finder-like 1:1:3:1:1 patterns
+	N4   int // deviation of the dark-module ratio from 50%
+}
+
+// Total returns the sum of the four rule scores, the value the specification
+// minimises.
+func (p MaskPenalty) Total() int {
+	return p.N1 + p.N2 + p.N3 + p.N4
+}
+
+// MaskCandidate is one of the eight masked symbols offered to a MaskSelector.
+// The grids are indexed [y][x] and are read-only: Pattern is shared with every
+// encode of the same version, and Modules is only valid during SelectMask.
+type MaskCandidate struct {
+	Mask    int
+	Pattern [][]bool // modules the mask flips (function modules never are)
+	Modules [][]bool // the masked symbol, format bits included
+	Penalty MaskPenalty
+}
+
+// MaskSelector chooses the mask pattern of a QR Code from its eight
+// candidates, ordered by mask number. Pass one to Encode with
+// WithMaskSelector.
+type MaskSelector interface {
+	SelectMask(candidates []MaskCandidate) (int, error)
+}
+
+// SpecMinimumMask returns the selector the encoder uses by default: the mask
+// with the lowest total penalty, the lower mask number winning ties.
+func SpecMinimumMask() MaskSelector {
+	return specMinimumMask{}
+}
+
+type specMinimumMask struct{}
+
+func (specMinimumMask) SelectMask(candidates []MaskCandidate) (int, error) {
+	best := 0
+	for i, c := range candidates {
+		if c.Penalty.Total() < candidates[best].Penalty.Total() {
+			best = i
+		}
+	}
+	return candidates[best].Mask, nil
+}
+
+// FixedMask returns a selector that always picks mask (0-7).
+func FixedMask(mask int) MaskSelector {
+	return fixedMask(mask)
+}
+
+type fixedMask int
+
+func (m fixedMask) SelectMask([]MaskCandidate) (int, error) {
+	return int(m), nil
+}
+
+// LightRegionMask returns a selector that keeps region, in module
+// coordinates, as light as possible, e.g. the area a logo will cover. It picks
+// the mask with the fewest dark modules inside region and breaks ties by total
+// penalty.
+func LightRegionMask(region image.Rectangle) MaskSelector {
+	return lightRegionMask{region: region.Canon()}
+}
+
+type lightRegionMask struct {
+	region image.Rectangle
+}
+
+func (s lightRegionMask) SelectMask(candidates []MaskCandidate) (int, error) {
+	best, bestDark := 0, -1
+	for i, c := range candidates {
+		r := s.region.Intersect(image.Rect(0, 0, len(c.Modules), len(c.Modules)))
+		dark := 0
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if c.Modules[y][x] {
+					dark++
+				}
+			}
+		}
+		if bestDark < 0 || dark < bestDark ||
+			dark == bestDark && c.Penalty.Total() < candidates[best].Penalty.Total() {
+			best, bestDark = i, dark
+		}
+	}
+	return candidates[best].Mask, nil
+}
+
+// selectMask returns the mask chosen by sel, or the lowest-penalty mask when
+// sel is nil.
+func (q *builder) selectMask(sel MaskSelector) (int, error) {
+	switch s := sel.(type) {
+	case nil:
+		return q.chooseBestMask(), nil
+	case fixedMask:
+		// No candidates needed.
+		if s < 0 || s > 7 {
+			return 0, fmt.Errorf("%w: mask %d out of range [0,7]", ErrInvalidArgument, int(s))
+		}
+		return int(s), nil
+	}
+	msk, err := sel.SelectMask(q.maskCandidates())
+	if err != nil {
+		return 0, err
+	}
+	if msk < 0 || msk > 7 {
+		return 0, fmt.Errorf("%w: mask selector returned %d", ErrInvalidArgument, msk)
+	}
+	return msk, nil
+}
+
+// maskCandidates renders the symbol under each of the eight masks, format
+// bits included, and scores it. Unlike chooseBestMask every grid is kept.
+func (q *builder) maskCandidates() []MaskCandidate {
+	tmpl := getTemplate(q.version)
+	res := make([]MaskCandidate, 8)
+	for i := range res {
+		q.drawFormatBits(i)
+		grid := make([][]bool, q.size)
+		backing := make([]bool, q.size*q.size)
+		for r := 0; r < q.size; r++ {
+			grid[r] = backing[r*q.size : (r+1)*q.size]
+		}
+		q.writeMaskedGrid(grid, tmpl.maskPatterns[i])
+		res[i] = MaskCandidate{
+			Mask:    i,
+			Pattern: tmpl.maskPatterns[i],
+			Modules: grid,
+			Penalty: q.getPenaltyBreakdown(grid),
+		}
+		res[i].Penalty.Mask = i
+	}
+	return res
+}
+
+// getPenaltyBreakdown scores grid like getPenaltyScore but keeps the four
+// rules apart. It favours clarity over speed; mask selection on the default
+// path still uses getPenaltyScore.
+func (q *builder) getPenaltyBreakdown(grid [][]bool) MaskPenalty {
+	var p MaskPenalty
+	size := q.size
+	var runHistory [7]int
+
+	// Rules 1 and 3, along rows and then along columns.
+	for pass := 0; pass < 2; pass++ {
+		for i := 0; i < size; i++ {
+			runColor, run := false, 0
+			runHistory = [7]int{}
+			for j := 0; j < size; j++ {
+				cell := grid[i][j]
+				if pass == 1 {
+					cell = grid[j][i]
+				}
+				if cell == runColor {
+					run++
+					if run == 5 {
+						p.N1 += penaltyN1
+					} else if run > 5 {
+						p.N1++
+					}
+				} else {
+					q.finderPenaltyAddHistory(run, runHistory[:])
+					if !runColor {
+						p.N3 += q.finderPenaltyCountPatterns(runHistory[:]) * penaltyN3
+					}
+					runColor = cell
+					run = 1
+				}
+			}
+			p.N3 += q.finderPenaltyTerminateAndCount(runColor, run, runHistory[:]) * penaltyN3
+		}
+	}
+
+	// Rule 2 and the dark-module tally for rule 4.
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			cell := grid[y][x]
+			if cell {
+				dark++
+			}
+			if y+1 < size && x+1 < size &&
+				cell == grid[y][x+1] && cell == grid[y+1][x] && cell == grid[y+1][x+1] {
+				p.N2 += penaltyN2
+			}
+		}
+	}
+
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	p.N4 = k * penaltyN4
+	return p
+}
+
+// Mask returns the mask pattern applied to the symbol: 0-7 for QR Code, 0-3
+// for Micro QR.
+func (q *QrCode) Mask() int {
+	return q.mask
+}
+
+// MaskPenalties re-scores the symbol under all eight mask patterns and
+// returns the breakdowns indexed by mask number, so the choice reported by
+// Mask can be audited. It returns nil for Micro QR and rMQR symbols, whose
+// masks are scored differently or fixed.
+func (q *QrCode) MaskPenalties() []MaskPenalty {
+	if q.symbol != SymbolQR {
+		return nil
+	}
+
+	// Rebuild the function patterns and undo the applied mask to recover the
+	// unmasked data modules.
+	b := newBuilder(q.version, q.errorCorrectionLevel)
+	b.drawFunctionPatterns()
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if !b.isFunction[y][x] {
+				b.modules[y][x] = q.modules[y][x] != maskInvert(q.mask, x, y)
+			}
+		}
+	}
+
+	res := make([]MaskPenalty, 8)
+	for i, c := range b.maskCandidates() {
+		res[i] = c.Penalty
+	}
+	return res
+}
@@ -0,0 +1,116 @@
+package go_qr
+
+import (
+	"errors"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPenaltyBreakdownMatchesScore checks that the per-rule breakdown adds up
+// to the fused getPenaltyScore for every mask, across the version range.
+func TestPenaltyBreakdownMatchesScore(t *testing.T) {
+	for _, text := range []string{"HELLO", "https://example.com/a/long/path?q=1", "0123456789012345678901234567890123456789"} {
+		for _, version := range []int{1, 2, 7, 10, 14, 27, 40} {
+			var got []MaskCandidate
+			qr, err := Encode(text, WithMinVersion(version), WithMaskSelector(recordingSelector{&got}))
+			if !assert.NoError(t, err) {
+				continue
+			}
+			assert.GreaterOrEqual(t, qr.version, version)
+			assert.Len(t, got, 8)
+
+			b := newBuilder(qr.version, qr.errorCorrectionLevel)
+			for i, c := range got {
+				assert.Equal(t, i, c.Mask)
+				assert.Equal(t, i, c.Penalty.Mask)
+				assert.Equal(t, b.getPenaltyScore(c.Modules, math.MaxInt32), c.Penalty.Total(), "version %d mask %d", qr.version, i)
+			}
+		}
+	}
+}
+
+type recordingSelector struct {
+	got *[]MaskCandidate
+}
+
+func (r recordingSelector) SelectMask(candidates []MaskCandidate) (int, error) {
+	*r.got = candidates
+	return SpecMinimumMask().SelectMask(candidates)
+}
+
+func TestMaskPenalties(t *testing.T) {
+	want, err := EncodeText("Audit the mask choice", Medium)
+	assert.NoError(t, err)
+	got, err := Encode("Audit the mask choice", WithEcc(Medium), WithMaskSelector(SpecMinimumMask()))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	penalties := want.MaskPenalties()
+	assert.Len(t, penalties, 8)
+	for _, p := range penalties {
+		assert.LessOrEqual(t, penalties[want.Mask()].Total(), p.Total())
+	}
+
+	micro, err := EncodeMicroText("12345", Low)
+	assert.NoError(t, err)
+	assert.Nil(t, micro.MaskPenalties())
+}
+
+func TestFixedMask(t *testing.T) {
+	for mask := 0; mask < 8; mask++ {
+		qr, err := Encode("fixed", WithMaskSelector(FixedMask(mask)))
+		assert.NoError(t, err)
+		assert.Equal(t, mask, qr.Mask())
+	}
+	_, err := Encode("fixed", WithMaskSelector(FixedMask(8)))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestLightRegionMask(t *testing.T) {
+	text := "https://example.com/products/12345"
+	region := image.Rect(10, 10, 19, 19)
+	qr, err := Encode(text, WithEcc(High), WithMaskSelector(LightRegionMask(region)))
+	assert.NoError(t, err)
+
+	darkIn := func(q *QrCode) int {
+		n := 0
+		for y := region.Min.Y; y < region.Max.Y; y++ {
+			for x := region.Min.X; x < region.Max.X; x++ {
+				if q.Module(x, y) {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	for mask := 0; mask < 8; mask++ {
+		other, err := Encode(text, WithEcc(High), WithMask(mask))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, darkIn(qr), darkIn(other), "mask %d", mask)
+	}
+
+	img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+	assert.NoError(t, err)
+	got, err := Decode(img)
+	assert.NoError(t, err)
+	assert.Equal(t, text, got)
+}
+
+type badSelector struct {
+	mask int
+	err  error
+}
+
+func (s badSelector) SelectMask([]MaskCandidate) (int, error) { return s.mask, s.err }
+
+func TestMaskSelectorErrors(t *testing.T) {
+	_, err := Encode("x", WithMaskSelector(badSelector{mask: 9}))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+	sentinel := errors.New("no mask suits")
+	_, err = Encode("x", WithMaskSelector(badSelector{err: sentinel}))
+	assert.True(t, errors.Is(err, sentinel))
+}
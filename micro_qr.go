@@ -0,0 +1,344 @@
+package go_qr
+
+import (
+	"fmt"
+
+	"github.com/piglig/go-qr/internal/reedsolomon"
+	"github.com/piglig/go-qr/qrspec"
+)
+
+// MinMicroVersion / MaxMicroVersion define the Micro QR version range M1-M4.
+const (
+	MinMicroVersion = 1
+	MaxMicroVersion = 4
+)
+
+// microDataBits is the data capacity in bits, indexed by Micro QR version and
+// error correction level. Zero marks a level the version does not offer: M1
+// only carries error detection (requested as Low), M2/M3 stop at Medium, M4
+// stops at Quartile, and no Micro QR version offers High.
+var microDataBits = [5][4]int{
+	{},                // index 0 is unused
+	{20, 0, 0, 0},     // M1
+	{40, 32, 0, 0},    // M2
+	{84, 68, 0, 0},    // M3
+	{128, 112, 80, 0}, // M4
+}
+
+// microEccCodewords is the number of error correction codewords, indexed by
+// Micro QR version and error correction level. Micro QR always uses one block.
+var microEccCodewords = [5][4]int{
+	{},
+	{2, 0, 0, 0},
+	{5, 6, 0, 0},
+	{6, 8, 0, 0},
+	{8, 10, 14, 0},
+}
+
+// microSymbolNumbers is the 3-bit symbol number written into the format
+// information, indexed by Micro QR version and error correction level.
+var microSymbolNumbers = [5][4]int{
+	{},
+	{0, -1, -1, -1},
+	{1, 2, -1, -1},
+	{3, 4, -1, -1},
+	{5, 6, 7, -1},
+}
+
+// microMaskPatterns maps the four Micro QR mask indices to the equivalent QR
+// Code mask number understood by maskInvert.
+var microMaskPatterns = [4]int{1, 4, 6, 7}
+
+// microHeader returns the Micro QR mode indicator and character-count width of
+// m in version ver. ok is false when the version cannot carry the mode: M1 is
+// numeric-only, M2 adds alphanumeric, and ECI is never available.
+func (m Mode) microHeader(ver int) (indicator, ccBits int, ok bool) {
+	switch {
+	case m.isNumeric():
+		return 0, ver + 2, true
+	case m.isAlphanumeric() && ver >= 2:
+		return 1, ver + 1, true
+	case m.isByte() && ver >= 3:
+		return 2, ver + 1, true
+	case m.isKanji() && ver >= 3:
+		return 3, ver, true
+	}
+	return 0, 0, false
+}
+
+// EncodeMicroText encodes text as the smallest Micro QR symbol that holds it
+// at the given error correction level, boosting the level when the data still
+// fits. It returns ErrDataTooLong when the text exceeds M4.
+func EncodeMicroText(text string, ecl Ecc) (*QrCode, error) {
+	segs, err := MakeSegments(text)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeMicroSegments(segs, ecl, MinMicroVersion, MaxMicroVersion, -1, true)
+}
+
+// EncodeMicroSegments is the Micro QR counterpart of EncodeSegments. Versions
+// are 1-4 for M1-M4, mask is -1 (auto) or 0-3, and versions that do not offer
+// ecl are skipped. Segments must use modes the chosen version supports; ECI
+// segments are rejected because Micro QR has no ECI mode.
+func EncodeMicroSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer, mask int, boostEcl bool) (*QrCode, error) {
+	if segs == nil {
+		return nil, fmt.Errorf("%w: segments slice is nil", ErrInvalidArgument)
+	}
+	if !(MinMicroVersion <= minVer && minVer <= maxVer && maxVer <= MaxMicroVersion) {
+		return nil, fmt.Errorf("%w: minVer=M%d maxVer=M%d", ErrInvalidVersion, minVer, maxVer)
+	}
+	if ecl < Low || ecl > High {
+		return nil, fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, ecl)
+	}
+	if mask < -1 || mask > 3 {
+		return nil, fmt.Errorf("%w: Micro QR mask %d out of range [-1,3]", ErrInvalidArgument, mask)
+	}
+
+	version, dataUsedBits, lastVer := 0, -1, 0
+	for ver := minVer; ver <= maxVer; ver++ {
+		capacity := microDataBits[ver][ecl]
+		if capacity == 0 {
+			continue
+		}
+		lastVer = ver
+		dataUsedBits = getMicroTotalBits(segs, ver)
+		if dataUsedBits != -1 && dataUsedBits <= capacity {
+			version = ver
+			break
+		}
+	}
+	if lastVer == 0 {
+		return nil, fmt.Errorf("%w: no Micro QR version in M%d-M%d offers ECC level %d", ErrInvalidArgument, minVer, maxVer, ecl)
+	}
+	if version == 0 {
+		return nil, &DataTooLongError{
+			RequiredBits: dataUsedBits, AvailableBits: microDataBits[lastVer][ecl], Version: lastVer, Ecc: ecl}
+	}
+
+	for _, newEcl := range []Ecc{Medium, Quartile} {
+		capacity := microDataBits[version][newEcl]
+		if boostEcl && newEcl > ecl && capacity != 0 && dataUsedBits <= capacity {
+			ecl = newEcl
+		}
+	}
+
+	bb := BitBuffer{}
+	for _, seg := range segs {
+		if seg == nil {
+			continue
+		}
+		indicator, ccBits, _ := seg.mode.microHeader(version)
+		if err := bb.appendBits(indicator, version-1); err != nil {
+			return nil, err
+		}
+		if err := bb.appendBits(seg.numChars, ccBits); err != nil {
+			return nil, err
+		}
+		if err := bb.appendData(seg.data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Terminator, bit padding and pad codewords. In M1 and M3 the final data
+	// codeword is only 4 bits long and is always filled with zeros.
+	capacity := microDataBits[version][ecl]
+	if err := bb.appendBits(0, min(2*version+1, capacity-bb.len())); err != nil {
+		return nil, err
+	}
+	if err := bb.appendBits(0, min((8-bb.len()%8)%8, capacity-bb.len())); err != nil {
+		return nil, err
+	}
+	for padByte := 0xEC; bb.len()+8 <= capacity; padByte ^= 0xEC ^ 0x11 {
+		if err := bb.appendBits(padByte, 8); err != nil {
+			return nil, err
+		}
+	}
+	if err := bb.appendBits(0, capacity-bb.len()); err != nil {
+		return nil, err
+	}
+
+	// The half codeword occupies the high nibble of a full byte, which is how
+	// it enters the Reed-Solomon computation.
+	dataCodewords := make([]byte, (capacity+7)/8)
+	for i := 0; i < bb.len(); i++ {
+		if bb.getBit(i) {
+			dataCodewords[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+	return newMicroQrCode(version, ecl, dataCodewords, mask)
+}
+
+// getMicroTotalBits returns the number of bits needed to encode segs in Micro
+// QR version ver, or -1 if a segment's mode is unavailable in that version or
+// its character count overflows the count field.
+func getMicroTotalBits(segs []*QrSegment, ver int) int {
+	res := 0
+	for _, seg := range segs {
+		if seg == nil {
+			continue
+		}
+		_, ccBits, ok := seg.mode.microHeader(ver)
+		if !ok || seg.numChars >= 1<<uint(ccBits) {
+			return -1
+		}
+		res += ver - 1 + ccBits + seg.data.len()
+	}
+	return res
+}
+
+// newMicroQrCode lays out a Micro QR symbol from its data codewords. msk is a
+// Micro QR mask index (0-3) or -1 to pick the highest-scoring mask.
+func newMicroQrCode(ver int, ecl Ecc, dataCodewords []byte, msk int) (*QrCode, error) {
+	b := newMicroBuilder(ver, ecl)
+	b.drawMicroFunctionPatterns()
+
+	bits, err := b.addMicroEcc(dataCodewords)
+	if err != nil {
+		return nil, err
+	}
+	b.drawMicroCodewords(bits)
+
+	if msk == -1 {
+		msk = b.chooseBestMicroMask()
+	}
+	if err := b.applyMask(microMaskPatterns[msk]); err != nil {
+		return nil, err
+	}
+	b.drawMicroFormatBits(msk)
+
+	return b.toQrCode(msk), nil
+}
+
+// newMicroBuilder allocates a blank builder for Micro QR version ver, whose
+// side length is 2*ver+9 modules.
+func newMicroBuilder(ver int, ecl Ecc) *builder {
+	b := newSizedBuilder(ver, ver*2+9, ver*2+9, ecl)
+	b.symbol = SymbolMicroQR
+	return b
+}
+
+// drawMicroFunctionPatterns draws the single finder pattern with its
+// separator, the timing patterns along the top row and left column, and
+// reserves the format information area.
+func (q *builder) drawMicroFunctionPatterns() {
+	q.drawFinderPattern(3, 3)
+	q.kind = ModuleTiming
+	for i := 8; i < q.size; i++ {
+		q.setFunctionModule(i, 0, i%2 == 0)
+		q.setFunctionModule(0, i, i%2 == 0)
+	}
+	q.drawMicroFormatBits(0)
+}
+
+// addMicroEcc computes the single Reed-Solomon block and returns the final
+// message as a bit sequence: the data bits (with the 4-bit half codeword of M1
+// and M3 kept at its real length) followed by the ECC codewords.
+func (q *builder) addMicroEcc(data []byte) (*BitBuffer, error) {
+	capacity := microDataBits[q.version][q.errorCorrectionLevel]
+	if len(data) != (capacity+7)/8 {
+		return nil, fmt.Errorf("%w: data length %d != expected %d", ErrInvalidArgument, len(data), (capacity+7)/8)
+	}
+
+	rsDiv, err := reedsolomon.Divisor(microEccCodewords[q.version][q.errorCorrectionLevel])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	ecc := reedsolomon.Remainder(data, rsDiv)
+
+	bb := &BitBuffer{}
+	for i := 0; i < capacity; i++ {
+		bb.appendBit(getBit(int(data[i>>3]), 7-(i&7)))
+	}
+	for _, b := range ecc {
+		if err := bb.appendBits(int(b), 8); err != nil {
+			return nil, err
+		}
+	}
+	return bb, nil
+}
+
+// drawMicroCodewords fills the non-function modules with the final message
+// bits. The zig-zag matches QR Code, except that no column is skipped (the
+// vertical timing pattern sits in column 0) and the first column pair runs
+// upward regardless of symbol size.
+func (q *builder) drawMicroCodewords(bits *BitBuffer) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		upward := (q.size-1-right)/2%2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !q.isFunction[y][x] && i < bits.len() {
+					q.modules[y][x] = bits.getBit(i)
+					if q.layout != nil {
+						q.layout[y][x] = q.microCodewordInfo(i)
+					}
+					i++
+				}
+			}
+		}
+	}
+}
+
+// microCodewordInfo locates bit i of the final message. The data bits end
+// with M1/M3's 4-bit half codeword, which counts as a whole codeword.
+func (q *builder) microCodewordInfo(i int) ModuleInfo {
+	capacity := microDataBits[q.version][q.errorCorrectionLevel]
+	if i >= capacity {
+		i -= capacity
+		return ModuleInfo{Kind: ModuleData, Codeword: (capacity+7)/8 + i/8, Bit: 7 - i%8}
+	}
+	return ModuleInfo{Kind: ModuleData, Codeword: i / 8, Bit: 7 - i%8}
+}
+
+// chooseBestMicroMask evaluates the four Micro QR masks and returns the one
+// with the highest score. Only the right column and bottom row contribute:
+// with SUM1 <= SUM2 the score is SUM1*16 + SUM2, so the mask that darkens the
+// weaker edge most wins.
+func (q *builder) chooseBestMicroMask() int {
+	last := q.size - 1
+	masked := func(msk, x, y int) bool {
+		return q.modules[y][x] != (maskInvert(msk, x, y) && !q.isFunction[y][x])
+	}
+
+	best, bestScore := 0, -1
+	for m, pattern := range microMaskPatterns {
+		sum1, sum2 := 0, 0
+		for i := 1; i < q.size; i++ {
+			if masked(pattern, last, i) {
+				sum1++
+			}
+			if masked(pattern, i, last) {
+				sum2++
+			}
+		}
+		score := sum2*16 + sum1
+		if sum1 <= sum2 {
+			score = sum1*16 + sum2
+		}
+		if score > bestScore {
+			best, bestScore = m, score
+		}
+	}
+	return best
+}
+
+// drawMicroFormatBits encodes the symbol number and mask into the 15-bit
+// format information, which Micro QR stores once, around the finder pattern.
+func (q *builder) drawMicroFormatBits(msk int) {
+	data := microSymbolNumbers[q.version][q.errorCorrectionLevel]<<2 | msk
+	bits := qrspec.FormatBCH(data) ^ 0x4445
+	q.kind = ModuleFormat
+
+	for i := 0; i < 8; i++ {
+		q.setFunctionModule(8, i+1, getBit(bits, i))
+	}
+	for i := 0; i < 7; i++ {
+		q.setFunctionModule(i+1, 8, getBit(bits, 14-i))
+	}
+}
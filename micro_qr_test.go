@@ -0,0 +1,120 @@
+package go_qr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piglig/go-qr/qrspec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMicroEccVector checks the final message against the M2-L "01234567"
+// example from ISO/IEC 18004 Annex I.
+func TestMicroEccVector(t *testing.T) {
+	b := newMicroBuilder(2, Low)
+	bits, err := b.addMicroEcc([]byte{0x40, 0x18, 0xAC, 0xC3, 0x00})
+	assert.NoError(t, err)
+
+	want := []byte{0x40, 0x18, 0xAC, 0xC3, 0x00, 0x86, 0x0D, 0x22, 0xAE, 0x30}
+	assert.Equal(t, len(want)*8, bits.len())
+	assert.Equal(t, want, bits.data)
+}
+
+func TestEncodeMicroVersionSelection(t *testing.T) {
+	cases := []struct {
+		name    string
+		text    string
+		ecl     Ecc
+		wantVer int
+		wantEcl Ecc
+	}{
+		{"M1 numeric, full capacity", "12345", Low, 1, Low},
+		{"M2 numeric boosted", "01234567", Low, 2, Medium},
+		{"M2 alphanumeric boosted", "AB", Low, 2, Medium},
+		{"M3 byte", "hello", Low, 3, Medium},
+		{"M4 quartile", "HELLO", Quartile, 4, Quartile},
+		{"M4 byte", "hello, world!!", Low, 4, Low},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			qr, err := EncodeMicroText(tc.text, tc.ecl)
+			assert.NoError(t, err)
+			assert.Equal(t, SymbolMicroQR, qr.SymbolType())
+			assert.Equal(t, tc.wantVer, qr.version)
+			assert.Equal(t, tc.wantEcl, qr.errorCorrectionLevel)
+			assert.Equal(t, tc.wantVer*2+9, qr.Size())
+			assert.Equal(t, 2, qr.QuietZone())
+		})
+	}
+}
+
+func TestEncodeMicroErrors(t *testing.T) {
+	_, err := EncodeMicroText("HELLO", High)
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "High: %v", err)
+
+	_, err = EncodeMicroText("this text is far too long for M4", Low)
+	assert.True(t, errors.Is(err, ErrDataTooLong), "long: %v", err)
+
+	eci, err := MakeEci(26)
+	assert.NoError(t, err)
+	_, err = EncodeMicroSegments([]*QrSegment{eci}, Low, MinMicroVersion, MaxMicroVersion, -1, false)
+	assert.True(t, errors.Is(err, ErrDataTooLong), "eci: %v", err)
+
+	_, err = EncodeMicroSegments(nil, Low, MinMicroVersion, MaxMicroVersion, -1, false)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+	_, err = EncodeMicroSegments([]*QrSegment{}, Low, 0, 5, -1, false)
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+
+	_, err = EncodeMicroSegments([]*QrSegment{}, Low, 1, 4, 4, false)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+// TestMicroFunctionPatterns checks the finder, separator, timing patterns and
+// that the format information decodes to the symbol number and mask.
+func TestMicroFunctionPatterns(t *testing.T) {
+	for mask := 0; mask < 4; mask++ {
+		seg, err := MakeNumeric("01234567")
+		assert.NoError(t, err)
+		qr, err := EncodeMicroSegments([]*QrSegment{seg}, Low, 2, 2, mask, false)
+		assert.NoError(t, err)
+		assert.Equal(t, mask, qr.mask)
+
+		for i := 0; i < 7; i++ {
+			assert.True(t, qr.Module(i, 0) && qr.Module(0, i) && qr.Module(i, 6) && qr.Module(6, i))
+		}
+		for i := 0; i < 8; i++ {
+			assert.False(t, qr.Module(7, i) || qr.Module(i, 7), "separator at %d", i)
+		}
+		for i := 8; i < qr.Size(); i++ {
+			assert.Equal(t, i%2 == 0, qr.Module(i, 0), "horizontal timing at %d", i)
+			assert.Equal(t, i%2 == 0, qr.Module(0, i), "vertical timing at %d", i)
+		}
+
+		var format int
+		for i := 0; i < 8; i++ {
+			if qr.Module(8, i+1) {
+				format |= 1 << i
+			}
+		}
+		for i := 0; i < 7; i++ {
+			if qr.Module(i+1, 8) {
+				format |= 1 << (14 - i)
+			}
+		}
+		assert.Equal(t, qrspec.FormatBCH(1<<2|mask), format^0x4445)
+	}
+}
+
+func TestMicroRenderWithQuietZone(t *testing.T) {
+	qr, err := EncodeMicroText("12345", Low)
+	assert.NoError(t, err)
+
+	img, err := qr.ToImage(NewQrCodeImgConfig(3, qr.QuietZone()))
+	assert.NoError(t, err)
+	assert.Equal(t, (11+4)*3, img.Bounds().Dx())
+
+	svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(3, qr.QuietZone(), WithOptimalSVG()))
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), "fill-rule=\"evenodd\"")
+}
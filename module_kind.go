@@ -0,0 +1,165 @@
+package go_qr
+
+import (
+	"sync"
+
+	"github.com/piglig/go-qr/qrspec"
+)
+
+// ModuleKind classifies what a module of a symbol belongs to.
+type ModuleKind int
+
+const (
+	ModuleNone             ModuleKind = iota // outside the symbol
+	ModuleData                               // a bit of a data codeword
+	ModuleECC                                // a bit of an error correction codeword
+	ModuleRemainder                          // remainder bit, after the last codeword
+	ModuleFinder                             // finder pattern
+	ModuleSeparator                          // light separator around a finder pattern
+	ModuleTiming                             // timing pattern
+	ModuleAlignment                          // alignment pattern
+	ModuleFormat                             // format information
+	ModuleVersion                            // version information (QR Code version 7 and up)
+	ModuleDarkModule                         // the always-dark module beside the bottom-left finder
+	ModuleFinderSubPattern                   // rMQR finder sub-pattern
+	ModuleCorner                             // rMQR corner pattern
+)
+
+var moduleKindNames = [...]string{
+	ModuleNone:             "none",
+	ModuleData:             "data",
+	ModuleECC:              "ecc",
+	ModuleRemainder:        "remainder",
+	ModuleFinder:           "finder",
+	ModuleSeparator:        "separator",
+	ModuleTiming:           "timing",
+	ModuleAlignment:        "alignment",
+	ModuleFormat:           "format",
+	ModuleVersion:          "version",
+	ModuleDarkModule:       "dark module",
+	ModuleFinderSubPattern: "finder sub-pattern",
+	ModuleCorner:           "corner",
+}
+
+// String returns a lower-case name for the kind.
+func (k ModuleKind) String() string {
+	if k < 0 || int(k) >= len(moduleKindNames) {
+		return "unknown"
+	}
+	return moduleKindNames[k]
+}
+
+// IsFunction reports whether the kind is a function pattern, i.e. neither a
+// codeword nor a remainder bit.
+func (k ModuleKind) IsFunction() bool {
+	return k >= ModuleFinder
+}
+
+// ModuleInfo describes one module. For data and ECC modules Codeword is the
+// index in the final, interleaved codeword sequence and Bit the bit within it
+// (7 is the most significant); both are -1 for every other kind.
+type ModuleInfo struct {
+	Kind     ModuleKind
+	Codeword int
+	Bit      int
+}
+
+// layoutKey identifies a module layout, which depends only on symbology,
+// version and ECC level, never on the payload or mask.
+type layoutKey struct {
+	symbol  SymbolType
+	version int
+	ecl     Ecc
+}
+
+// layoutCache maps layoutKey -> [][]ModuleInfo. Layouts are immutable once
+// built.
+var layoutCache sync.Map
+
+// ModuleKind reports what the module at (x, y) belongs to, or ModuleNone
+// outside the symbol.
+func (q *QrCode) ModuleKind(x, y int) ModuleKind {
+	if x < 0 || x >= q.size || y < 0 || y >= len(q.modules) {
+		return ModuleNone
+	}
+	return q.layout()[y][x].Kind
+}
+
+// ModuleLayout returns the classification of every module, indexed [y][x].
+// The grid is a fresh copy.
+func (q *QrCode) ModuleLayout() [][]ModuleInfo {
+	layout := q.layout()
+	res := make([][]ModuleInfo, len(layout))
+	backing := make([]ModuleInfo, q.size*len(layout))
+	for y, row := range layout {
+		res[y] = backing[y*q.size : (y+1)*q.size]
+		copy(res[y], row)
+	}
+	return res
+}
+
+// layout returns the cached layout of the symbol, building it on first use.
+func (q *QrCode) layout() [][]ModuleInfo {
+	return cachedLayout(layoutKey{symbol: q.symbol, version: q.version, ecl: q.errorCorrectionLevel})
+}
+
+// cachedLayout returns the layout for key from layoutCache, building it on
+// first use.
+func cachedLayout(key layoutKey) [][]ModuleInfo {
+	if v, ok := layoutCache.Load(key); ok {
+		return v.([][]ModuleInfo)
+	}
+	actual, _ := layoutCache.LoadOrStore(key, buildModuleLayout(key))
+	return actual.([][]ModuleInfo)
+}
+
+// buildModuleLayout replays the drawing of a blank symbol with recording
+// switched on, then tells data codewords from ECC codewords.
+func buildModuleLayout(key layoutKey) [][]ModuleInfo {
+	var b *builder
+	var numData int
+	switch key.symbol {
+	case SymbolMicroQR:
+		b = newMicroBuilder(key.version, key.ecl)
+		b.initLayout()
+		b.drawMicroFunctionPatterns()
+		capacity := microDataBits[key.version][key.ecl]
+		numData = (capacity + 7) / 8
+		bits, _ := b.addMicroEcc(make([]byte, numData))
+		b.drawMicroCodewords(bits)
+	case SymbolRMQR:
+		b = newRMQRBuilder(key.version, key.ecl)
+		b.initLayout()
+		b.drawRMQRFunctionPatterns()
+		numData = getRMQRNumDataCodewords(key.version, key.ecl)
+		b.drawRMQRCodewords(make([]byte, getRMQRNumRawDataModules(key.version)/8))
+	default:
+		b = newBuilder(key.version, key.ecl)
+		b.initLayout()
+		b.drawFunctionPatterns()
+		numData = getNumDataCodewords(key.version, key.ecl)
+		_ = b.drawCodewords(make([]byte, qrspec.TotalCodewords(key.version)))
+	}
+
+	for _, row := range b.layout {
+		for x := range row {
+			if row[x].Kind == ModuleData && row[x].Codeword >= numData {
+				row[x].Kind = ModuleECC
+			}
+		}
+	}
+	return b.layout
+}
+
+// initLayout switches on module recording; modules that are never drawn stay
+// remainder bits.
+func (q *builder) initLayout() {
+	q.layout = make([][]ModuleInfo, q.height)
+	backing := make([]ModuleInfo, q.size*q.height)
+	for i := range backing {
+		backing[i] = ModuleInfo{Kind: ModuleRemainder, Codeword: -1, Bit: -1}
+	}
+	for y := range q.layout {
+		q.layout[y] = backing[y*q.size : (y+1)*q.size]
+	}
+}
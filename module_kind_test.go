@@ -0,0 +1,115 @@
+package go_qr
+
+import (
+	"testing"
+
+	"github.com/piglig/go-qr/qrspec"
+	"github.com/stretchr/testify/assert"
+)
+
+func countKinds(layout [][]ModuleInfo) map[ModuleKind]int {
+	counts := map[ModuleKind]int{}
+	for _, row := range layout {
+		for _, m := range row {
+			counts[m.Kind]++
+		}
+	}
+	return counts
+}
+
+func TestModuleLayoutVersion1(t *testing.T) {
+	qr, err := EncodeText("HELLO", Medium)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, qr.version)
+
+	assert.Equal(t, map[ModuleKind]int{
+		ModuleFinder:     3 * 49,
+		ModuleSeparator:  3 * 15,
+		ModuleTiming:     2 * 5,
+		ModuleFormat:     30,
+		ModuleDarkModule: 1,
+		ModuleData:       getNumDataCodewords(1, qr.errorCorrectionLevel) * 8,
+		ModuleECC:        (26 - getNumDataCodewords(1, qr.errorCorrectionLevel)) * 8,
+	}, countKinds(qr.ModuleLayout()))
+
+	assert.Equal(t, ModuleFinder, qr.ModuleKind(0, 0))
+	assert.Equal(t, ModuleSeparator, qr.ModuleKind(7, 0))
+	assert.Equal(t, ModuleTiming, qr.ModuleKind(6, 9))
+	assert.Equal(t, ModuleFormat, qr.ModuleKind(8, 0))
+	assert.Equal(t, ModuleDarkModule, qr.ModuleKind(8, 13))
+	assert.Equal(t, ModuleNone, qr.ModuleKind(-1, 0))
+	assert.Equal(t, ModuleNone, qr.ModuleKind(0, 21))
+	assert.True(t, ModuleAlignment.IsFunction())
+	assert.False(t, ModuleECC.IsFunction())
+	assert.Equal(t, "dark module", ModuleDarkModule.String())
+
+	// The first codeword starts in the bottom-right corner.
+	assert.Equal(t, ModuleInfo{Kind: ModuleData, Codeword: 0, Bit: 7}, qr.ModuleLayout()[20][20])
+}
+
+func TestModuleLayoutFunctionPatterns(t *testing.T) {
+	qr, err := EncodeSegments([]*QrSegment{}, Low, 7, 7, 0, false)
+	assert.NoError(t, err)
+	counts := countKinds(qr.ModuleLayout())
+	assert.Equal(t, 36, counts[ModuleVersion])
+	// Six alignment patterns of 25 modules.
+	assert.Equal(t, 6*25, counts[ModuleAlignment])
+	assert.Equal(t, 0, counts[ModuleRemainder])
+
+	qr, err = EncodeSegments([]*QrSegment{}, Low, 2, 2, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, countKinds(qr.ModuleLayout())[ModuleRemainder])
+}
+
+// TestModuleLayoutMatchesCodewords checks every data/ECC module against the
+// codewords the decoder reads back from the unmasked symbol.
+func TestModuleLayoutMatchesCodewords(t *testing.T) {
+	qr, err := EncodeText("https://example.com/module-layout", Quartile)
+	assert.NoError(t, err)
+
+	b := newBuilder(qr.version, qr.errorCorrectionLevel)
+	b.drawFunctionPatterns()
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if !b.isFunction[y][x] {
+				b.modules[y][x] = qr.modules[y][x] != maskInvert(qr.mask, x, y)
+			}
+		}
+	}
+	raw := b.readCodewords()
+	assert.Len(t, raw, qrspec.TotalCodewords(qr.version))
+
+	for y, row := range qr.ModuleLayout() {
+		for x, m := range row {
+			if m.Kind != ModuleData && m.Kind != ModuleECC {
+				assert.Equal(t, -1, m.Codeword)
+				continue
+			}
+			want := raw[m.Codeword]>>uint(m.Bit)&1 == 1
+			assert.Equal(t, want, b.modules[y][x], "(%d,%d) codeword %d bit %d", x, y, m.Codeword, m.Bit)
+		}
+	}
+}
+
+func TestModuleLayoutMicroAndRMQR(t *testing.T) {
+	micro, err := EncodeMicroSegments([]*QrSegment{}, Low, 1, 1, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[ModuleKind]int{
+		ModuleFinder:    49,
+		ModuleSeparator: 15,
+		ModuleTiming:    6,
+		ModuleFormat:    15,
+		ModuleData:      20, // M1's last data codeword is 4 bits
+		ModuleECC:       2 * 8,
+	}, countKinds(micro.ModuleLayout()))
+
+	rmqr, err := EncodeRMQRText("RMQR", Medium)
+	assert.NoError(t, err)
+	counts := countKinds(rmqr.ModuleLayout())
+	numData := getRMQRNumDataCodewords(rmqr.version, rmqr.errorCorrectionLevel)
+	assert.Equal(t, numData*8, counts[ModuleData])
+	assert.Equal(t, (getRMQRNumRawDataModules(rmqr.version)/8-numData)*8, counts[ModuleECC])
+	assert.Equal(t, 25, counts[ModuleFinderSubPattern])
+	assert.Zero(t, counts[ModuleNone])
+	assert.Equal(t, ModuleFormat, rmqr.ModuleKind(8, 1))
+}
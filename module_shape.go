@@ -0,0 +1,231 @@
+package go_qr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ModuleShape is the outline drawn for each dark module.
+type ModuleShape int
+
+const (
+	ModuleSquare         ModuleShape = iota // the full module square (default)
+	ModuleCircle                            // a dot inscribed in the module
+	ModuleRoundedSquare                     // a square with corners rounded by a quarter module
+	ModuleDiamond                           // a square rotated 45°, corners on the module edge midpoints
+	ModuleVerticalBars                      // vertically adjacent modules join into round-ended bars
+	ModuleHorizontalBars                    // horizontally adjacent modules join into round-ended bars
+)
+
+// Shape geometry, in module units.
+const (
+	roundedSquareRadius = 0.25
+	barInset            = 0.1 // gap on each long side of a bar
+)
+
+// valid reports whether s is one of the defined shapes.
+func (s ModuleShape) valid() bool {
+	return s >= ModuleSquare && s <= ModuleHorizontalBars
+}
+
+// WithModuleShape draws dark data, ECC, format and version modules as shape,
+// in PNG, ToImage and SVG output alike. Function patterns (finders,
+// separators, timing and alignment patterns) stay square unless
+// WithFunctionPatternShape says otherwise. Scanners need the module centers
+// to stay dark, which every shape does.
+func WithModuleShape(shape ModuleShape) Option {
+	return func(q *QrCodeImgConfig) {
+		q.moduleShape = shape
+	}
+}
+
+// WithFunctionPatternShape draws the dark modules of finder, timing and
+// alignment patterns as shape; the default is ModuleSquare.
+func WithFunctionPatternShape(shape ModuleShape) Option {
+	return func(q *QrCodeImgConfig) {
+		q.patternShape = shape
+	}
+}
+
+// isPattern reports whether modules of kind k are drawn with the function
+// pattern shape.
+func (k ModuleKind) isPattern() bool {
+	switch k {
+	case ModuleFinder, ModuleSeparator, ModuleTiming, ModuleAlignment, ModuleFinderSubPattern, ModuleCorner:
+		return true
+	}
+	return false
+}
+
+// moduleShaper decides the outline of every dark module of a symbol under a
+// config. The PNG and SVG renderers share it so both draw the same geometry.
+type moduleShaper struct {
+	q             *QrCode
+	layout        [][]ModuleInfo
+	data, pattern ModuleShape
+}
+
+// newModuleShaper returns the shaper for config, or nil when every module is
+// a plain square.
+func (q *QrCode) newModuleShaper(config *QrCodeImgConfig) *moduleShaper {
+	if config.moduleShape == ModuleSquare && config.patternShape == ModuleSquare {
+		return nil
+	}
+	return &moduleShaper{
+		q:       q,
+		layout:  q.layout(),
+		data:    config.moduleShape,
+		pattern: config.patternShape,
+	}
+}
+
+// shape returns the shape of the module at (x, y).
+func (s *moduleShaper) shape(x, y int) ModuleShape {
+	if s.layout[y][x].Kind.isPattern() {
+		return s.pattern
+	}
+	return s.data
+}
+
+// joined reports whether the dark module at (x, y) merges into the one at
+// (x+dx, y+dy): both dark and drawn with the same shape.
+func (s *moduleShaper) joined(x, y, dx, dy int, shape ModuleShape) bool {
+	nx, ny := x+dx, y+dy
+	return s.q.Module(nx, ny) && s.shape(nx, ny) == shape
+}
+
+// contains reports whether the point (fx, fy), in module units relative to
+// the top-left corner of the dark module at (x, y), lies inside its shape.
+func (s *moduleShaper) contains(x, y int, fx, fy float64) bool {
+	shape := s.shape(x, y)
+	switch shape {
+	case ModuleCircle:
+		return sq(fx-0.5)+sq(fy-0.5) <= 0.25
+	case ModuleRoundedSquare:
+		r := roundedSquareRadius
+		cx := min(max(fx, r), 1-r)
+		cy := min(max(fy, r), 1-r)
+		return sq(fx-cx)+sq(fy-cy) <= r*r
+	case ModuleDiamond:
+		return math.Abs(fx-0.5)+math.Abs(fy-0.5) <= 0.5
+	case ModuleVerticalBars, ModuleHorizontalBars:
+		along, across := fy, fx
+		prev, next := s.joined(x, y, 0, -1, shape), s.joined(x, y, 0, 1, shape)
+		if shape == ModuleHorizontalBars {
+			along, across = fx, fy
+			prev, next = s.joined(x, y, -1, 0, shape), s.joined(x, y, 1, 0, shape)
+		}
+		r := 0.5 - barInset
+		if sq(along-0.5)+sq(across-0.5) <= r*r {
+			return true
+		}
+		if across < barInset || across > 1-barInset {
+			return false
+		}
+		return prev && along <= 0.5 || next && along >= 0.5
+	}
+	return true
+}
+
+// writePath appends the outline of the dark module at (x, y) to a path, as
+// a closed clockwise subpath; ox, oy is the module's top-left corner in
+// output units and scale its side.
+func (s *moduleShaper) writePath(p pathWriter, x, y int, ox, oy, scale float64) {
+	pt := func(cmd byte, px, py float64) {
+		if cmd == 'M' {
+			p.moveTo(px, py)
+		} else {
+			p.lineTo(px, py)
+		}
+	}
+	arc := func(r, px, py float64) { p.arcTo(r, px, py, true) }
+
+	shape := s.shape(x, y)
+	switch shape {
+	case ModuleCircle:
+		r := scale / 2
+		pt('M', ox, oy+r)
+		arc(r, ox+scale, oy+r)
+		arc(r, ox, oy+r)
+	case ModuleRoundedSquare:
+		r := scale * roundedSquareRadius
+		pt('M', ox+r, oy)
+		pt('L', ox+scale-r, oy)
+		arc(r, ox+scale, oy+r)
+		pt('L', ox+scale, oy+scale-r)
+		arc(r, ox+scale-r, oy+scale)
+		pt('L', ox+r, oy+scale)
+		arc(r, ox, oy+scale-r)
+		pt('L', ox, oy+r)
+		arc(r, ox+r, oy)
+	case ModuleDiamond:
+		pt('M', ox+scale/2, oy)
+		pt('L', ox+scale, oy+scale/2)
+		pt('L', ox+scale/2, oy+scale)
+		pt('L', ox, oy+scale/2)
+	case ModuleVerticalBars:
+		r := scale * (0.5 - barInset)
+		x0, x1 := ox+scale*barInset, ox+scale*(1-barInset)
+		top, bottom := oy+scale/2, oy+scale/2
+		if s.joined(x, y, 0, -1, shape) {
+			top = oy
+		}
+		if s.joined(x, y, 0, 1, shape) {
+			bottom = oy + scale
+		}
+		pt('M', x0, top)
+		if top == oy {
+			pt('L', x1, top)
+		} else {
+			arc(r, x1, top)
+		}
+		pt('L', x1, bottom)
+		if bottom == oy+scale {
+			pt('L', x0, bottom)
+		} else {
+			arc(r, x0, bottom)
+		}
+	case ModuleHorizontalBars:
+		r := scale * (0.5 - barInset)
+		y0, y1 := oy+scale*barInset, oy+scale*(1-barInset)
+		left, right := ox+scale/2, ox+scale/2
+		if s.joined(x, y, -1, 0, shape) {
+			left = ox
+		}
+		if s.joined(x, y, 1, 0, shape) {
+			right = ox + scale
+		}
+		pt('M', left, y1)
+		if left == ox {
+			pt('L', left, y0)
+		} else {
+			arc(r, left, y0)
+		}
+		pt('L', right, y0)
+		if right == ox+scale {
+			pt('L', right, y1)
+		} else {
+			arc(r, right, y1)
+		}
+	default:
+		pt('M', ox, oy)
+		pt('L', ox+scale, oy)
+		pt('L', ox+scale, oy+scale)
+		pt('L', ox, oy+scale)
+	}
+	p.closePath()
+}
+
+// writeFloat writes v with at most three decimals and no trailing zeros.
+func writeFloat(sb *strings.Builder, v float64) {
+	sb.WriteString(formatFloat(v))
+}
+
+// formatFloat formats v with at most three decimals and no trailing zeros.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
+
+// sq returns v squared.
+func sq(v float64) float64 { return v * v }
@@ -0,0 +1,138 @@
+package go_qr
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allModuleShapes = []ModuleShape{
+	ModuleSquare, ModuleCircle, ModuleRoundedSquare, ModuleDiamond, ModuleVerticalBars, ModuleHorizontalBars,
+}
+
+func TestModuleShapeDecodes(t *testing.T) {
+	text := "https://example.com/shapes"
+	qr, err := EncodeText(text, Medium)
+	assert.NoError(t, err)
+	for _, shape := range allModuleShapes {
+		img, err := qr.ToImage(NewQrCodeImgConfig(8, 4, WithModuleShape(shape)))
+		assert.NoError(t, err)
+		decoded, err := Decode(img)
+		assert.NoError(t, err, "shape %d", shape)
+		assert.Equal(t, text, decoded, "shape %d", shape)
+	}
+}
+
+func TestModuleShapeArea(t *testing.T) {
+	qr, err := EncodeText("AREA", Low)
+	assert.NoError(t, err)
+	const scale = 40
+	r := roundedSquareRadius
+	want := map[ModuleShape]float64{
+		ModuleSquare:        1,
+		ModuleCircle:        math.Pi / 4,
+		ModuleRoundedSquare: 1 - (4-math.Pi)*r*r,
+		ModuleDiamond:       0.5,
+	}
+	for shape, area := range want {
+		img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0, WithModuleShape(shape)))
+		assert.NoError(t, err)
+		// Average over every dark data module.
+		var dark, total int
+		for y := 0; y < qr.Size(); y++ {
+			for x := 0; x < qr.Size(); x++ {
+				if !qr.Module(x, y) || qr.ModuleKind(x, y) != ModuleData {
+					continue
+				}
+				for py := y * scale; py < (y+1)*scale; py++ {
+					for px := x * scale; px < (x+1)*scale; px++ {
+						if img.RGBAAt(px, py) == (color.RGBA{A: 255}) {
+							dark++
+						}
+						total++
+					}
+				}
+			}
+		}
+		// Pixel centers on a diagonal edge count as inside.
+		assert.InDelta(t, area, float64(dark)/float64(total), 0.03, "shape %d", shape)
+	}
+}
+
+func TestModuleShapeFunctionPatterns(t *testing.T) {
+	qr, err := EncodeText("PATTERNS", Low)
+	assert.NoError(t, err)
+	const scale = 10
+	img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0, WithModuleShape(ModuleCircle)))
+	assert.NoError(t, err)
+	// The top-left finder corner stays square by default...
+	assert.Equal(t, color.RGBA{A: 255}, img.RGBAAt(0, 0))
+	// ...and follows WithFunctionPatternShape when set.
+	img, err = qr.ToImage(NewQrCodeImgConfig(scale, 0, WithModuleShape(ModuleCircle), WithFunctionPatternShape(ModuleCircle)))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, img.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{A: 255}, img.RGBAAt(scale/2, scale/2))
+}
+
+func TestModuleShapeBars(t *testing.T) {
+	qr, err := EncodeText("BARS", Low)
+	assert.NoError(t, err)
+	const scale = 10
+	img, err := qr.ToImage(NewQrCodeImgConfig(scale, 0, WithModuleShape(ModuleVerticalBars), WithFunctionPatternShape(ModuleVerticalBars)))
+	assert.NoError(t, err)
+	black := color.RGBA{A: 255}
+	// The left column of the top-left finder is one bar: the boundary between
+	// its first two modules is filled, the gap beside it is not.
+	assert.Equal(t, black, img.RGBAAt(scale/2, scale))
+	assert.NotEqual(t, black, img.RGBAAt(0, scale))
+	// Its top end is rounded.
+	assert.NotEqual(t, black, img.RGBAAt(1, 1))
+
+	img, err = qr.ToImage(NewQrCodeImgConfig(scale, 0, WithModuleShape(ModuleHorizontalBars), WithFunctionPatternShape(ModuleHorizontalBars)))
+	assert.NoError(t, err)
+	assert.Equal(t, black, img.RGBAAt(scale, scale/2))
+	assert.NotEqual(t, black, img.RGBAAt(scale, 0))
+}
+
+func TestModuleShapeSVG(t *testing.T) {
+	qr, err := EncodeText("SVG", Low)
+	assert.NoError(t, err)
+	for _, optimal := range []bool{false, true} {
+		opts := []Option{WithModuleShape(ModuleCircle)}
+		if optimal {
+			opts = append(opts, WithOptimalSVG())
+		}
+		svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, opts...))
+		assert.NoError(t, err)
+		s := string(svg)
+		// Every dark data module is one circle of two arcs.
+		var circles int
+		for y := 0; y < qr.Size(); y++ {
+			for x := 0; x < qr.Size(); x++ {
+				if qr.Module(x, y) && !qr.ModuleKind(x, y).isPattern() {
+					circles++
+				}
+			}
+		}
+		assert.Equal(t, circles*2, strings.Count(s, "A5,5 0 0 1 "), "optimal=%v", optimal)
+		// The circle of data module (9, 0) starts at the left end of its
+		// horizontal diameter.
+		assert.True(t, qr.Module(9, 0))
+		assert.Contains(t, s, "M94,9A5,5 0 0 1 104,9A5,5 0 0 1 94,9Z")
+	}
+
+	// Squares are unchanged without a shape.
+	plain, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4))
+	assert.NoError(t, err)
+	square, err := qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithModuleShape(ModuleSquare)))
+	assert.NoError(t, err)
+	assert.Equal(t, plain, square)
+
+	_, err = qr.ToSVGBytes(NewQrCodeImgConfig(10, 4, WithModuleShape(ModuleShape(99))))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = qr.ToPNGBytes(NewQrCodeImgConfig(10, 4, WithFunctionPatternShape(ModuleShape(-1))))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -17,17 +17,43 @@ func (q *QrCode) toSvgOptimizedString(config *QrCodeImgConfig, lightColor, darkC
 		sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
 		sb.WriteString("<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n")
 	}
-	n := q.Size()*scale + border*2
+	w := q.Width()*scale + border*2
+	h := q.Height()*scale + border*2
 	sb.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" version=\"1.1\" viewBox=\"0 0 %d %d\" stroke=\"none\">\n",
-		n, n))
+		w, h))
 	if lightColor != "" {
 		sb.WriteString("\t<rect width=\"100%\" height=\"100%\" fill=\"" + lightColor + "\"/>\n")
 	}
 
-	graph := q.assembleBorderGraph()
+	if g := config.gradient; g != nil {
+		g.writeSVGDefs(&sb, float64(border), float64(border), float64(q.Width()*scale), float64(q.Height()*scale))
+	}
+
 	sb.WriteString("\t<path d=\"")
-	graph.writePath(&sb, border, scale)
+	shaper := q.newModuleShaper(config)
+	eyes := q.styledEyes(config)
+	// Square modules merge into outlines; shaped ones are drawn one by one
+	// and styled eyes get paths of their own.
+	merged := q.mergedModules(shaper, eyes)
+	graph := q.assembleBorderGraphOf(merged)
+	if config.cornerRadius > 0 {
+		graph.writeOutline(svgPath{&sb}, border, scale, config.cornerRadius)
+	} else {
+		graph.writePath(&sb, border, scale)
+	}
+	if shaper != nil {
+		for y := 0; y < q.Height(); y++ {
+			for x := 0; x < q.Width(); x++ {
+				if q.Module(x, y) && eyeAt(eyes, x, y) == nil && !merged(x, y) {
+					shaper.writePath(svgPath{&sb}, x, y, float64(x*scale+border), float64(y*scale+border), float64(scale))
+				}
+			}
+		}
+	}
 	sb.WriteString("\" fill=\"" + darkColor + "\" fill-rule=\"evenodd\"/>\n")
+	for i := range eyes {
+		eyes[i].writeSVG(&sb, float64(eyes[i].x*scale+border), float64(eyes[i].y*scale+border), float64(scale), darkColor)
+	}
 	sb.WriteString("</svg>\n")
 
 	return sb.String()
@@ -64,11 +90,11 @@ func (e edges) formCorner() bool {
 }
 
 // borderGraph is a deterministic, array-backed replacement for the previous
-// map[node]edges. Grid points live on (n+1) × (n+1); the `top` flag doubles
+// map[node]edges. Grid points live on (w+1) × (h+1); the `top` flag doubles
 // that for the diagonal-touch disambiguation. Iteration order is fixed, which
 // makes output byte-stable and enables golden-file regression tests.
 type borderGraph struct {
-	stride int // n + 1
+	stride int // h + 1
 	// Flat slice indexed by ((x*stride+y)<<1) | topBit. Presence is tracked
 	// separately because a zero-valued edges struct is a legal value (edge to
 	// node{0,0,false}).
@@ -76,9 +102,9 @@ type borderGraph struct {
 	exists []bool
 }
 
-func newBorderGraph(n int) *borderGraph {
-	stride := n + 1
-	size := stride * stride * 2
+func newBorderGraph(w, h int) *borderGraph {
+	stride := h + 1
+	size := (w + 1) * stride * 2
 	return &borderGraph{
 		stride: stride,
 		data:   make([]edges, size),
@@ -111,6 +137,37 @@ func (g *borderGraph) get(n node) edges {
 // writePath walks the graph in deterministic index order and writes the SVG
 // path `d` attribute data for every closed loop.
 func (g *borderGraph) writePath(sb *strings.Builder, border, scale int) {
+	for _, corners := range g.loops() {
+		startX, startY := corners[0].imageXY(border, scale)
+		sb.WriteByte('M')
+		writeInt(sb, startX)
+		sb.WriteByte(',')
+		writeInt(sb, startY)
+
+		for i := 1; i < len(corners); i++ {
+			prev, cur := corners[i-1], corners[i]
+			if prev.x == cur.x {
+				_, y := cur.imageXY(border, scale)
+				sb.WriteByte('V')
+				writeInt(sb, y)
+			} else {
+				x, _ := cur.imageXY(border, scale)
+				sb.WriteByte('H')
+				writeInt(sb, x)
+			}
+		}
+		sb.WriteByte('L')
+		writeInt(sb, startX)
+		sb.WriteByte(',')
+		writeInt(sb, startY)
+	}
+}
+
+// loops walks the graph in deterministic index order and returns the corner
+// nodes of every closed loop, in walking order. Consecutive corners always
+// differ in exactly one coordinate.
+func (g *borderGraph) loops() [][]node {
+	var res [][]node
 	visited := make([]bool, len(g.exists))
 
 	for idx := range g.exists {
@@ -123,12 +180,7 @@ func (g *borderGraph) writePath(sb *strings.Builder, border, scale int) {
 			continue
 		}
 
-		startX, startY := startNode.imageXY(border, scale)
-		sb.WriteByte('M')
-		writeInt(sb, startX)
-		sb.WriteByte(',')
-		writeInt(sb, startY)
-
+		corners := []node{startNode}
 		prev := startNode
 		cur := startNode
 		next := startEdges.first
@@ -142,24 +194,14 @@ func (g *borderGraph) writePath(sb *strings.Builder, border, scale int) {
 				next = curEdges.first
 			}
 			if curEdges.formCorner() {
-				if prev.x == cur.x {
-					_, y := cur.imageXY(border, scale)
-					sb.WriteByte('V')
-					writeInt(sb, y)
-				} else {
-					x, _ := cur.imageXY(border, scale)
-					sb.WriteByte('H')
-					writeInt(sb, x)
-				}
+				corners = append(corners, cur)
 			}
 			visited[g.index(cur.x, cur.y, cur.top)] = true
 		}
-		sb.WriteByte('L')
-		writeInt(sb, startX)
-		sb.WriteByte(',')
-		writeInt(sb, startY)
 		visited[idx] = true
+		res = append(res, corners)
 	}
+	return res
 }
 
 func (g *borderGraph) nodeAt(idx int) node {
@@ -175,17 +217,34 @@ func writeInt(sb *strings.Builder, v int) {
 // assembleBorderGraph builds the border graph of all connected filled regions
 // in the QR code. Borders between two adjacent filled modules are omitted.
 func (q *QrCode) assembleBorderGraph() *borderGraph {
-	n := q.Size()
-	g := newBorderGraph(n)
-	for y := 0; y < n; y++ {
-		for x := 0; x < n; x++ {
-			if !q.Module(x, y) {
+	return q.assembleBorderGraphOf(q.Module)
+}
+
+// mergedModules returns the filter of modules merged into outlines: dark
+// modules drawn as squares and not covered by a styled eye.
+func (q *QrCode) mergedModules(shaper *moduleShaper, eyes []eye) func(x, y int) bool {
+	if shaper == nil && eyes == nil {
+		return q.Module
+	}
+	return func(x, y int) bool {
+		return q.Module(x, y) && eyeAt(eyes, x, y) == nil && (shaper == nil || shaper.shape(x, y) == ModuleSquare)
+	}
+}
+
+// assembleBorderGraphOf is assembleBorderGraph over the modules for which
+// filled returns true.
+func (q *QrCode) assembleBorderGraphOf(filled func(x, y int) bool) *borderGraph {
+	w, h := q.Width(), q.Height()
+	g := newBorderGraph(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !filled(x, y) {
 				continue
 			}
-			top := y == 0 || !q.Module(x, y-1)
-			right := x == n-1 || !q.Module(x+1, y)
-			bottom := y == n-1 || !q.Module(x, y+1)
-			left := x == 0 || !q.Module(x-1, y)
+			top := y == 0 || !filled(x, y-1)
+			right := x == w-1 || !filled(x+1, y)
+			bottom := y == h-1 || !filled(x, y+1)
+			left := x == 0 || !filled(x-1, y)
 
 			if top {
 				l := node{x: x, y: y}
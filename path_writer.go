@@ -0,0 +1,49 @@
+package go_qr
+
+import "strings"
+
+// pathWriter receives outlines as path commands in output units. Module
+// shapes, styled eyes and rounded outlines describe their geometry once
+// through it, and the SVG and PDF renderers turn it into their own syntax.
+type pathWriter interface {
+	moveTo(x, y float64)
+	lineTo(x, y float64)
+	// arcTo draws a circular arc of radius r, at most a half turn, from the
+	// current point to (x, y), clockwise or counterclockwise on screen.
+	arcTo(r, x, y float64, clockwise bool)
+	closePath()
+}
+
+// svgPath writes path commands as SVG path data.
+type svgPath struct {
+	sb *strings.Builder
+}
+
+func (p svgPath) moveTo(x, y float64) { p.point('M', x, y) }
+
+func (p svgPath) lineTo(x, y float64) { p.point('L', x, y) }
+
+func (p svgPath) arcTo(r, x, y float64, clockwise bool) {
+	p.sb.WriteByte('A')
+	writeFloat(p.sb, r)
+	p.sb.WriteByte(',')
+	writeFloat(p.sb, r)
+	if clockwise {
+		p.sb.WriteString(" 0 0 1 ")
+	} else {
+		p.sb.WriteString(" 0 0 0 ")
+	}
+	writeFloat(p.sb, x)
+	p.sb.WriteByte(',')
+	writeFloat(p.sb, y)
+}
+
+func (p svgPath) closePath() { p.sb.WriteByte('Z') }
+
+// point writes the command cmd to (x, y).
+func (p svgPath) point(cmd byte, x, y float64) {
+	p.sb.WriteByte(cmd)
+	writeFloat(p.sb, x)
+	p.sb.WriteByte(',')
+	writeFloat(p.sb, y)
+}
@@ -0,0 +1,102 @@
+package go_qr
+
+import "errors"
+
+// SegmentPlan is one segment of an EncodePlan with the bits it takes at the
+// planned version: mode indicator, character count field and data.
+type SegmentPlan struct {
+	Segment  *QrSegment
+	Mode     Mode
+	NumChars int
+	Bits     int
+}
+
+// EncodePlan explains how Encode would lay out a text without building the
+// symbol. Plan defaults to SegmentationOptimal while Encode defaults to
+// SegmentationStandard, so a plan describes Encode only when both are given
+// the same WithSegmentation option.
+type EncodePlan struct {
+	Segments []SegmentPlan
+
+	Version      int // smallest version that fits, or the maximum allowed one if none does
+	RequestedEcc Ecc // the level asked for
+	Ecc          Ecc // the level after boosting
+	Boosted      bool
+
+	UsedBits     int // sum of Segments[i].Bits, before terminator and padding
+	CapacityBits int // data capacity at Version and Ecc (getNumDataCodewords * 8)
+	HeadroomBits int // CapacityBits - UsedBits; negative when the text does not fit
+}
+
+// Fits reports whether the text fits the planned version.
+func (p *EncodePlan) Fits() bool {
+	return p.HeadroomBits >= 0
+}
+
+// Plan reports how text would be encoded at ecl: the segments
+// MakeSegmentsOptimally chooses, the bits each takes, the chosen version, its
+// capacity, the remaining headroom and whether the ECC level was boosted.
+// Options are those of Encode, except that segmentation defaults to
+// SegmentationOptimal; the mask options are checked but otherwise ignored.
+// Nothing is rendered.
+//
+// When the text is too long Plan returns the plan at the largest allowed
+// version together with an ErrDataTooLong error, so the shortfall can be
+// reported.
+func Plan(text string, ecl Ecc, options ...EncodeOption) (*EncodePlan, error) {
+	c := &encodeConfig{
+		ecl:          ecl,
+		minVersion:   MinVersion,
+		maxVersion:   MaxVersion,
+		mask:         -1,
+		boostEcl:     true,
+		segmentation: SegmentationOptimal,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	segs, err := c.segments(text)
+	if errors.Is(err, ErrDataTooLong) {
+		// Segment for the largest version so the shortfall is measured
+		// against the best layout available.
+		if c.binary {
+			segs, err = makeBinarySegmentsWithVersion([]byte(text), c.maxVersion)
+		} else {
+			codePoints, cpErr := toCodePoints(text)
+			if cpErr != nil {
+				return nil, cpErr
+			}
+			segs, err = makeSegmentsOptimallyWithVersion(codePoints, c.maxVersion, c.segmentation == SegmentationOptimalHanzi)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version, usedBits, fitErr := fitVersion(segs, c.ecl, c.minVersion, c.maxVersion)
+	p := &EncodePlan{Version: version, RequestedEcc: c.ecl, Ecc: c.ecl}
+	if fitErr == nil && c.boostEcl {
+		p.Ecc = boostEcc(c.ecl, version, usedBits)
+		p.Boosted = p.Ecc != c.ecl
+	}
+
+	for _, seg := range segs {
+		if seg == nil {
+			continue
+		}
+		p.Segments = append(p.Segments, SegmentPlan{
+			Segment:  seg,
+			Mode:     seg.mode,
+			NumChars: seg.numChars,
+			Bits:     seg.mode.headerBits(version) + seg.data.len(),
+		})
+		p.UsedBits += p.Segments[len(p.Segments)-1].Bits
+	}
+	p.CapacityBits = getNumDataCodewords(version, p.Ecc) * 8
+	p.HeadroomBits = p.CapacityBits - p.UsedBits
+	return p, fitErr
+}
@@ -0,0 +1,92 @@
+package go_qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanMatchesEncode(t *testing.T) {
+	text := "PROJECT-1234567890 details"
+	p, err := Plan(text, Low)
+	assert.NoError(t, err)
+
+	qr, err := Encode(text, WithSegmentation(SegmentationOptimal))
+	assert.NoError(t, err)
+	assert.Equal(t, qr.version, p.Version)
+	assert.Equal(t, qr.errorCorrectionLevel, p.Ecc)
+	assert.Equal(t, Low, p.RequestedEcc)
+	assert.True(t, p.Boosted)
+	assert.True(t, p.Fits())
+
+	segs, err := MakeSegmentsOptimally(text, Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	assert.Len(t, p.Segments, len(segs))
+	for i, sp := range p.Segments {
+		assert.Equal(t, getTotalBits(segs[i:i+1], p.Version), sp.Bits)
+	}
+	assert.Equal(t, getTotalBits(segs, p.Version), p.UsedBits)
+	assert.Equal(t, getNumDataCodewords(p.Version, p.Ecc)*8, p.CapacityBits)
+	assert.Equal(t, p.CapacityBits-p.UsedBits, p.HeadroomBits)
+}
+
+func TestPlanOptions(t *testing.T) {
+	p, err := Plan("HELLO", Low, WithBoostEcl(false), WithMinVersion(3))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, p.Version)
+	assert.Equal(t, Low, p.Ecc)
+	assert.False(t, p.Boosted)
+
+	// Standard segmentation puts the whole mixed text in one byte segment.
+	p, err = Plan("abc123456789012345", Low, WithSegmentation(SegmentationStandard))
+	assert.NoError(t, err)
+	assert.Len(t, p.Segments, 1)
+	assert.True(t, p.Segments[0].Mode.isByte())
+
+	_, err = Plan("x", Low, WithMinVersion(0))
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+}
+
+func TestPlanDataTooLong(t *testing.T) {
+	p, err := Plan(strings.Repeat("a", 200), High, WithMaxVersion(5))
+	assert.True(t, errors.Is(err, ErrDataTooLong), "%v", err)
+	assert.NotNil(t, p)
+	assert.Equal(t, 5, p.Version)
+	assert.Equal(t, High, p.Ecc)
+	assert.False(t, p.Fits())
+	assert.Equal(t, 4+8+200*8, p.UsedBits)
+	assert.Equal(t, getNumDataCodewords(5, High)*8-p.UsedBits, p.HeadroomBits)
+}
+
+func TestPlanBinaryDataTooLong(t *testing.T) {
+	// Bytes that are not UTF-8 must be measured as themselves, one byte
+	// each, not as U+FFFD replacement characters.
+	data := strings.Repeat("\xff", 150) + strings.Repeat("0123456789", 6)
+	p, err := Plan(data, High, WithBinaryInput(), WithMaxVersion(5))
+	assert.True(t, errors.Is(err, ErrDataTooLong), "%v", err)
+	assert.NotNil(t, p)
+	assert.Equal(t, 5, p.Version)
+	if assert.Len(t, p.Segments, 2) {
+		assert.True(t, p.Segments[0].Mode.isByte())
+		assert.Equal(t, 150, p.Segments[0].NumChars)
+		assert.True(t, p.Segments[1].Mode.isNumeric())
+		assert.Equal(t, 60, p.Segments[1].NumChars)
+	}
+	assert.Equal(t, 4+8+150*8+4+10+60/3*10, p.UsedBits)
+}
+
+func TestPlanEmptyText(t *testing.T) {
+	for _, s := range []Segmentation{SegmentationOptimal, SegmentationOptimalHanzi, SegmentationStandard} {
+		p, err := Plan("", Low, WithSegmentation(s))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, p.Version)
+		assert.Equal(t, 0, p.UsedBits)
+		assert.True(t, p.Fits())
+
+		qr, err := Encode("", WithSegmentation(s))
+		assert.NoError(t, err)
+		assert.Equal(t, qr.version, p.Version)
+	}
+}
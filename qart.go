@@ -0,0 +1,282 @@
+package go_qr
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/piglig/go-qr/internal/reedsolomon"
+	"github.com/piglig/go-qr/qrspec"
+)
+
+// EncodeQArt encodes text as a QR Code whose dark and light modules resemble
+// target, in the manner of Russ Cox's QArt codes. The symbol stays standard:
+// the message, terminator, ECC and function patterns are untouched, so any
+// reader, Decode included, returns text.
+//
+// The picture is drawn with the bits after the terminator, which readers
+// ignore. Reed-Solomon encoding is linear, so each block's data and ECC
+// modules are affine functions of its free bits; Gaussian elimination over
+// GF(2) then sets as many modules as the free bits allow, darkest and
+// lightest parts of target first. Every mask is tried (or only the WithMask
+// one) and the closest match wins.
+//
+// target is stretched over the symbol, quiet zone excluded; transparent
+// pixels are left to the padding. More free bits give a better likeness: use
+// WithMinVersion for a larger symbol and keep WithEcc low. WithBoostEcl and
+// WithMaskSelector are ignored, since a higher ECC level leaves fewer free
+// bits.
+func EncodeQArt(text string, target image.Image, options ...EncodeOption) (*QrCode, error) {
+	if target == nil || target.Bounds().Empty() {
+		return nil, fmt.Errorf("%w: target image is empty", ErrInvalidArgument)
+	}
+	c := &encodeConfig{
+		ecl:        Low,
+		minVersion: MinVersion,
+		maxVersion: MaxVersion,
+		mask:       -1,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	segs, err := c.segments(text)
+	if err != nil {
+		return nil, err
+	}
+	version, _, err := fitVersion(segs, c.ecl, c.minVersion, c.maxVersion)
+	if err != nil {
+		return nil, err
+	}
+	data, terminated, err := makeDataCodewords(segs, version, c.ecl)
+	if err != nil {
+		return nil, err
+	}
+
+	art, err := newQArt(version, c.ecl, data, terminated)
+	if err != nil {
+		return nil, err
+	}
+	targets := sampleQArtTarget(target, qrspec.Size(version))
+
+	masks := []int{c.mask}
+	if c.mask == -1 {
+		masks = []int{0, 1, 2, 3, 4, 5, 6, 7}
+	}
+	var best *QrCode
+	bestScore := math.Inf(-1)
+	for _, mask := range masks {
+		qr, err := newQrCode(version, c.ecl, art.solve(mask, targets), fixedMask(mask))
+		if err != nil {
+			return nil, err
+		}
+		score := 0.0
+		for _, t := range targets {
+			if qr.modules[t.y][t.x] == t.dark {
+				score += t.weight
+			}
+		}
+		if score > bestScore {
+			best, bestScore = qr, score
+		}
+	}
+	return best, nil
+}
+
+// qartTarget is the wanted color of one module and how much it matters.
+type qartTarget struct {
+	x, y   int
+	dark   bool
+	weight float64
+}
+
+// sampleQArtTarget averages target over a size×size grid and returns the
+// modules with a preference, strongest first.
+func sampleQArtTarget(target image.Image, size int) []qartTarget {
+	b := target.Bounds()
+	var res []qartTarget
+	for y := 0; y < size; y++ {
+		y0 := b.Min.Y + y*b.Dy()/size
+		y1 := max(b.Min.Y+(y+1)*b.Dy()/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := b.Min.X + x*b.Dx()/size
+			x1 := max(b.Min.X+(x+1)*b.Dx()/size, x0+1)
+
+			var lum, alpha float64
+			for py := y0; py < y1; py++ {
+				for px := x0; px < x1; px++ {
+					r, g, bl, a := target.At(px, py).RGBA()
+					lum += (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 0xffff
+					alpha += float64(a) / 0xffff
+				}
+			}
+			if alpha == 0 {
+				continue
+			}
+			// Colors are alpha-premultiplied; un-premultiply the average.
+			lum /= alpha
+			alpha /= float64((y1 - y0) * (x1 - x0))
+			weight := math.Abs(lum-0.5) * 2 * alpha
+			if weight > 0 {
+				res = append(res, qartTarget{x: x, y: y, dark: lum < 0.5, weight: weight})
+			}
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].weight > res[j].weight })
+	return res
+}
+
+// qart holds, per Reed-Solomon block, how the block's codeword bits depend
+// on its free data bits.
+type qart struct {
+	version   int
+	ecl       Ecc
+	data      []byte
+	structure qrspec.Blocks
+	blocks    []qartBlock
+}
+
+// qartBlock describes one block. Bit b of codeword p of the block equals
+// base's bit XOR the parity of rows[p*8+b] AND the free bits.
+type qartBlock struct {
+	free []int      // data stream bit index of each free bit
+	base []byte     // data and ECC codewords with every free bit unchanged
+	rows [][]uint64 // bitsets over free
+}
+
+// newQArt splits the data codewords into blocks as addEccAndInterLeave does
+// and derives each block's rows from the Reed-Solomon remainder of every
+// single free bit.
+func newQArt(version int, ecl Ecc, data []byte, terminated int) (*qart, error) {
+	structure := qrspec.BlockStructure(version, qrspec.Level(ecl))
+	rsDiv, err := reedsolomon.Divisor(structure.ECCodewordsPerBlock)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+
+	art := &qart{version: version, ecl: ecl, data: data, structure: structure}
+	art.blocks = make([]qartBlock, structure.NumBlocks)
+	for j := range art.blocks {
+		blk := &art.blocks[j]
+		off := structure.DataOffset(j)
+		k := structure.DataCodewords(j)
+		dat := data[off : off+k]
+		blk.base = append(append([]byte{}, dat...), reedsolomon.Remainder(dat, rsDiv)...)
+
+		for g := max(terminated, off*8); g < (off+k)*8; g++ {
+			blk.free = append(blk.free, g)
+		}
+		words := (len(blk.free) + 63) / 64
+		blk.rows = make([][]uint64, len(blk.base)*8)
+		for i := range blk.rows {
+			blk.rows[i] = make([]uint64, words)
+		}
+
+		unit := make([]byte, k)
+		for v, g := range blk.free {
+			p, b := g/8-off, 7-g%8
+			blk.rows[p*8+b][v/64] |= 1 << (v % 64)
+
+			unit[p] = 1 << b
+			for t, e := range reedsolomon.Remainder(unit, rsDiv) {
+				for bit := 0; bit < 8; bit++ {
+					if e>>bit&1 == 1 {
+						blk.rows[(k+t)*8+bit][v/64] |= 1 << (v % 64)
+					}
+				}
+			}
+			unit[p] = 0
+		}
+	}
+	return art, nil
+}
+
+// solve returns data codewords whose free bits make as many targets as
+// possible come out right under mask, in target order.
+func (a *qart) solve(mask int, targets []qartTarget) []byte {
+	layout := cachedLayout(layoutKey{symbol: SymbolQR, version: a.version, ecl: a.ecl})
+
+	// Sort the wanted codeword bits into their blocks.
+	type equation struct {
+		row int
+		bit bool
+	}
+	wanted := make([][]equation, len(a.blocks))
+	for _, t := range targets {
+		m := layout[t.y][t.x]
+		if m.Kind != ModuleData && m.Kind != ModuleECC {
+			continue
+		}
+		j := a.structure.CodewordBlock(m.Codeword)
+		row := a.structure.CodewordIndex(m.Codeword)*8 + m.Bit
+		bit := t.dark != maskInvert(mask, t.x, t.y)
+		wanted[j] = append(wanted[j], equation{row: row, bit: bit})
+	}
+
+	res := append([]byte{}, a.data...)
+	for j, blk := range a.blocks {
+		// Incremental reduced row echelon form: pivots[c] is the row whose
+		// leading free bit is c; no row has a bit set in another row's pivot
+		// column.
+		words := (len(blk.free) + 63) / 64
+		type reduced struct {
+			vec []uint64
+			rhs bool
+		}
+		pivots := map[int]*reduced{}
+		for _, eq := range wanted[j] {
+			if len(pivots) == len(blk.free) {
+				break
+			}
+			base := blk.base[eq.row/8]>>(eq.row%8)&1 == 1
+			r := &reduced{vec: append([]uint64{}, blk.rows[eq.row]...), rhs: eq.bit != base}
+			for w := 0; w < words; w++ {
+				for m := r.vec[w]; m != 0; m &= m - 1 {
+					c := w*64 + bits.TrailingZeros64(m)
+					if p, ok := pivots[c]; ok {
+						xorReduced(r.vec, p.vec)
+						r.rhs = r.rhs != p.rhs
+					}
+				}
+			}
+			lead := -1
+			for w := 0; w < words && lead < 0; w++ {
+				if r.vec[w] != 0 {
+					lead = w*64 + bits.TrailingZeros64(r.vec[w])
+				}
+			}
+			if lead < 0 {
+				// Already decided by stronger targets.
+				continue
+			}
+			for _, p := range pivots {
+				if p.vec[lead/64]>>(lead%64)&1 == 1 {
+					xorReduced(p.vec, r.vec)
+					p.rhs = p.rhs != r.rhs
+				}
+			}
+			pivots[lead] = r
+		}
+
+		// Non-pivot free bits keep their padding value.
+		for c, p := range pivots {
+			if p.rhs {
+				g := blk.free[c]
+				res[g/8] ^= 1 << (7 - g%8)
+			}
+		}
+	}
+	return res
+}
+
+// xorReduced sets dst to dst XOR src.
+func xorReduced(dst, src []uint64) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
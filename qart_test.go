@@ -0,0 +1,110 @@
+package go_qr
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/piglig/go-qr/internal/reedsolomon"
+	"github.com/stretchr/testify/assert"
+)
+
+// diskImage is a black disc on white, transparent outside radius 2r.
+func diskImage(size, r int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	c := size / 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			d := (x-c)*(x-c) + (y-c)*(y-c)
+			switch {
+			case d < r*r:
+				img.Set(x, y, color.Black)
+			case d < 4*r*r:
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+	return img
+}
+
+// qartMatch returns the fraction of the sampled targets the symbol honors.
+func qartMatch(qr *QrCode, target image.Image) float64 {
+	targets := sampleQArtTarget(target, qr.Size())
+	hit := 0
+	for _, t := range targets {
+		if qr.Module(t.x, t.y) == t.dark {
+			hit++
+		}
+	}
+	return float64(hit) / float64(len(targets))
+}
+
+func TestEncodeQArt(t *testing.T) {
+	const text = "https://example.com/qart"
+	target := diskImage(200, 40)
+
+	qr, err := EncodeQArt(text, target, WithMinVersion(8))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, qr.version)
+
+	// The picture shows through far better than with plain padding.
+	plain, err := EncodeSegments(mustSegments(t, text), Low, 8, 8, qr.Mask(), false)
+	assert.NoError(t, err)
+	assert.Greater(t, qartMatch(qr, target), 0.85)
+	assert.Greater(t, qartMatch(qr, target), qartMatch(plain, target)+0.2)
+
+	// Every block's ECC matches its data, and readers get the original text.
+	b := newBuilder(qr.version, qr.errorCorrectionLevel)
+	b.drawFunctionPatterns()
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if !b.isFunction[y][x] {
+				b.modules[y][x] = qr.modules[y][x] != maskInvert(qr.mask, x, y)
+			}
+		}
+	}
+	structure := qr.blockStructure()
+	blocks := make([][]byte, structure.NumBlocks)
+	for i, c := range b.readCodewords() {
+		j := structure.CodewordBlock(i)
+		assert.Equal(t, len(blocks[j]), structure.CodewordIndex(i))
+		blocks[j] = append(blocks[j], c)
+	}
+	div, err := reedsolomon.Divisor(structure.ECCodewordsPerBlock)
+	assert.NoError(t, err)
+	for j, blk := range blocks {
+		k := structure.DataCodewords(j)
+		assert.Equal(t, blk[k:], reedsolomon.Remainder(blk[:k], div), "block %d", j)
+	}
+
+	decoded, err := Decode(mustImage(t, qr))
+	assert.NoError(t, err)
+	assert.Equal(t, text, decoded)
+}
+
+func TestEncodeQArtOptions(t *testing.T) {
+	target := diskImage(60, 12)
+
+	qr, err := EncodeQArt("HELLO", target, WithMask(3), WithEcc(Medium), WithMaxVersion(3))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, qr.Mask())
+	assert.Equal(t, Medium, qr.errorCorrectionLevel)
+	text, err := Decode(mustImage(t, qr))
+	assert.NoError(t, err)
+	assert.Equal(t, "HELLO", text)
+
+	_, err = EncodeQArt("HELLO", nil)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = EncodeQArt("HELLO", target, WithMask(9))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = EncodeQArt("HELLO", target, WithMinVersion(0))
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+	_, err = EncodeQArt("0123456789012345678901234567890123456789012345", target, WithMaxVersion(1))
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func mustImage(t *testing.T, qr *QrCode) image.Image {
+	img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+	assert.NoError(t, err)
+	return img
+}
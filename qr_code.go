@@ -1,6 +1,6 @@
 package go_qr
 
-import "fmt"
+import "github.com/piglig/go-qr/qrspec"
 
 // Ecc is the representation of an error correction level in a QR Code symbol.
 type Ecc int
@@ -12,61 +12,74 @@ const (
 	High                // 30% of codewords can be restored
 )
 
-// eccFormats maps the ECC to its respective format bits.
-var eccFormats = [...]int{1, 0, 3, 2}
-
 // FormatBits returns the format bits associated with the error correction level.
 func (e Ecc) FormatBits() int {
-	return eccFormats[e]
+	return qrspec.Level(e).FormatBits()
 }
 
+// SymbolType identifies the symbology a QrCode was encoded as. Every symbol
+// type renders through the same PNG/SVG/ToImage entry points.
+type SymbolType int
+
+const (
+	SymbolQR      SymbolType = iota // QR Code Model 2, versions 1-40
+	SymbolMicroQR                   // Micro QR Code, versions M1-M4
+	SymbolRMQR                      // Rectangular Micro QR Code, versions R7x43-R17x139
+)
+
 // MinVersion / MaxVersion define the supported QR Code Model 2 version range.
 const (
 	MinVersion = 1
 	MaxVersion = 40
 )
 
-// eccCodeWordsPerBlock is a lookup table for the number of error correction
-// code words per block, indexed by error correction level and version.
-var eccCodeWordsPerBlock = [][]int8{
-	// Version: (note that index 0 is for padding, and is set to an illegal value)
-	//0,  1,  2,  3,  4,  5,  6,  7,  8,  9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40    Error correction level
-	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},  // Low
-	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}, // Medium
-	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // Quartile
-	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // High
+// rmqrDimensions is the height and width in modules of each rMQR version
+// (R7x43 ... R17x139), ordered by height, then width.
+var rmqrDimensions = [][2]int{
+	{-1, -1}, // index 0 is unused
+	{7, 43}, {7, 59}, {7, 77}, {7, 99}, {7, 139},
+	{9, 43}, {9, 59}, {9, 77}, {9, 99}, {9, 139},
+	{11, 27}, {11, 43}, {11, 59}, {11, 77}, {11, 99}, {11, 139},
+	{13, 27}, {13, 43}, {13, 59}, {13, 77}, {13, 99}, {13, 139},
+	{15, 43}, {15, 59}, {15, 77}, {15, 99}, {15, 139},
+	{17, 43}, {17, 59}, {17, 77}, {17, 99}, {17, 139},
 }
 
-// numErrorCorrectionBlocks is a lookup table for the number of error
-// correction blocks, indexed by error correction level and version.
-var numErrorCorrectionBlocks = [][]int8{
-	// Version: (note that index 0 is for padding, and is set to an illegal value)
-	//0, 1, 2, 3, 4, 5, 6, 7, 8, 9,10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40    Error correction level
-	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},              // Low
-	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},     // Medium
-	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},  // Quartile
-	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81}, // High
+// rmqrEccCodeWordsPerBlock is the number of error correction codewords per
+// rMQR block, indexed by error correction level row and version.
+// rMQR only offers Medium and High, so the table has one row for each.
+var rmqrEccCodeWordsPerBlock = [][]int8{
+	// Version: (index 0 is padding)
+	//0, 1,  2,  3,  4,  5,  6,  7,  8,  9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32    Error correction level
+	{-1, 7, 9, 12, 16, 24, 9, 12, 18, 24, 18, 8, 12, 16, 24, 16, 24, 9, 14, 22, 16, 20, 20, 18, 26, 18, 24, 24, 22, 16, 22, 20, 20},      // Medium
+	{-1, 10, 14, 22, 30, 22, 14, 22, 16, 22, 22, 10, 20, 16, 22, 30, 30, 14, 28, 20, 28, 26, 28, 18, 24, 24, 22, 26, 20, 30, 28, 26, 26}, // High
+}
+
+// rmqrNumErrorCorrectionBlocks is the number of rMQR error correction blocks,
+// with one row each for Medium and High.
+var rmqrNumErrorCorrectionBlocks = [][]int8{
+	// Version: (index 0 is padding)
+	//0, 1, 2, 3, 4, 5, 6, 7, 8, 9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31,32    Error correction level
+	{-1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 2, 2, 1, 1, 1, 2, 2, 3, 1, 1, 2, 2, 3, 1, 2, 2, 3, 4}, // Medium
+	{-1, 1, 1, 1, 1, 2, 1, 1, 2, 2, 3, 1, 1, 2, 2, 2, 3, 1, 1, 2, 2, 3, 4, 2, 2, 3, 4, 5, 2, 2, 3, 4, 6}, // High
 }
 
 // QrCode is an immutable, fully-rendered QR Code symbol. Build one with
 // EncodeText / EncodeSegments; query it with Size and Module; render it with the
 // PNG/SVG methods. It carries no build-time scratch (see builder).
 type QrCode struct {
-	version              int      // Version of the QR Code.
-	size                 int      // Side length in modules.
-	errorCorrectionLevel Ecc      // Error correction level (ECC).
-	mask                 int      // Mask pattern applied.
-	modules              [][]bool // Dark/light state of every module (read-only).
+	version              int        // Version of the QR Code.
+	size                 int        // Width in modules; the side length of square symbols.
+	errorCorrectionLevel Ecc        // Error correction level (ECC).
+	mask                 int        // Mask pattern applied.
+	modules              [][]bool   // Dark/light state of every module (read-only).
+	symbol               SymbolType // Symbology (QR Code, Micro QR or rMQR).
 }
 
 // newQrCode encodes the data codewords into a finished QrCode at the given
-// version and ECC level. msk selects the mask pattern; -1 chooses the
+// version and ECC level. sel chooses the mask pattern; nil chooses the
 // lowest-penalty mask. It drives a builder and freezes the result.
-func newQrCode(ver int, ecl Ecc, dataCodewords []byte, msk int) (*QrCode, error) {
-	if msk < -1 || msk > 7 {
-		return nil, fmt.Errorf("%w: mask value out of range", ErrInvalidArgument)
-	}
-
+func newQrCode(ver int, ecl Ecc, dataCodewords []byte, sel MaskSelector) (*QrCode, error) {
 	b := newBuilder(ver, ecl)
 	b.drawFunctionPatterns()
 
@@ -78,8 +91,9 @@ func newQrCode(ver int, ecl Ecc, dataCodewords []byte, msk int) (*QrCode, error)
 		return nil, err
 	}
 
-	if msk == -1 {
-		msk = b.chooseBestMask()
+	msk, err := b.selectMask(sel)
+	if err != nil {
+		return nil, err
 	}
 	if err := b.applyMask(msk); err != nil {
 		return nil, err
@@ -89,14 +103,41 @@ func newQrCode(ver int, ecl Ecc, dataCodewords []byte, msk int) (*QrCode, error)
 	return b.toQrCode(msk), nil
 }
 
-// Size returns the side length of the QR code in modules.
+// Size returns the side length of the QR code in modules. For rectangular
+// rMQR symbols it returns the width; use Width and Height instead.
 func (q *QrCode) Size() int {
 	return q.size
 }
 
+// Width returns the horizontal extent of the symbol in modules.
+func (q *QrCode) Width() int {
+	return q.size
+}
+
+// Height returns the vertical extent of the symbol in modules. It equals Width
+// for every symbology except rMQR.
+func (q *QrCode) Height() int {
+	return len(q.modules)
+}
+
+// SymbolType reports which symbology the code was encoded as.
+func (q *QrCode) SymbolType() SymbolType {
+	return q.symbol
+}
+
+// QuietZone returns the minimum light border, in modules, that the symbology
+// requires around the symbol: 4 for QR Code and 2 for Micro QR and rMQR. Pass
+// it as the border to NewQrCodeImgConfig to render the smallest compliant image.
+func (q *QrCode) QuietZone() int {
+	if q.symbol == SymbolMicroQR || q.symbol == SymbolRMQR {
+		return 2
+	}
+	return 4
+}
+
 // Module reports whether the module at (x, y) is dark.
 func (q *QrCode) Module(x, y int) bool {
-	return 0 <= x && x < q.size && 0 <= y && y < q.size && q.modules[y][x]
+	return 0 <= x && x < q.size && 0 <= y && y < len(q.modules) && q.modules[y][x]
 }
 
 // getBit returns the i-th bit (LSB-first) of x.
@@ -5,6 +5,9 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/piglig/go-qr/qrspec"
 )
 
 // Mode is the representation of the mode of a QR code character. It is
@@ -12,6 +15,7 @@ import (
 type Mode struct {
 	modeBits         int    // 4-bit mode indicator used in QR Code's data encoding
 	numBitsCharCount [3]int // char-count-indicator bits for the three version ranges
+	subset           int    // 4-bit subset indicator after modeBits, 0 if none (Hanzi)
 }
 
 // newMode creates a new Mode with a given mode indicator and the character count
@@ -23,25 +27,45 @@ func newMode(mode, cc1, cc2, cc3 int) Mode {
 	}
 }
 
+// newSpecMode creates the Mode for one of the qrspec data modes.
+func newSpecMode(m qrspec.Mode) Mode {
+	return newMode(m.Indicator(),
+		qrspec.CharCountBits(m, 1), qrspec.CharCountBits(m, 10), qrspec.CharCountBits(m, 27))
+}
+
 // numCharCountBits returns the number of character count bits
 // for a specific QR code version.
 func (m Mode) numCharCountBits(ver int) int {
 	return m.numBitsCharCount[(ver+7)/17]
 }
 
+// headerBits returns the length of the segment header in version ver: mode
+// indicator, subset indicator if any, and character count.
+func (m Mode) headerBits(ver int) int {
+	if m.subset != 0 {
+		return 8 + m.numCharCountBits(ver)
+	}
+	return 4 + m.numCharCountBits(ver)
+}
+
 // Predefined Mode values as defined by the QR Code standard.
 var (
 	// Numeric mode is typically used for decimal digits (0 through 9).
-	Numeric = newMode(0x1, 10, 12, 14)
+	Numeric = newSpecMode(qrspec.Numeric)
 
 	// Alphanumeric mode includes digits 0-9, uppercase letters A-Z and nine special characters.
-	Alphanumeric = newMode(0x2, 9, 11, 13)
+	Alphanumeric = newSpecMode(qrspec.Alphanumeric)
 
 	// Byte mode can encode binary/byte data(default: ISO-8859-1)
-	Byte = newMode(0x4, 8, 16, 16) // Byte mode: binary/byte data (default: ISO-8859-1)
+	Byte = newSpecMode(qrspec.Byte)
 
 	// Kanji mode is used for encoding Japanese Kanji characters.
-	Kanji = newMode(0x8, 8, 10, 12)
+	Kanji = newSpecMode(qrspec.Kanji)
+
+	// Hanzi mode encodes GB 2312 Chinese characters in 13 bits each, as
+	// defined by GB/T 18284. Its mode indicator is followed by the GB 2312
+	// subset indicator. Readers outside China may not support it.
+	Hanzi = Mode{modeBits: 0xD, numBitsCharCount: [3]int{8, 10, 12}, subset: hanziSubsetGB2312}
 
 	// Eci mode is designed for providing a method of extending features and functions
 	// in bar code symbols beyond those envisioned by the original standard.
@@ -65,9 +89,48 @@ func (m Mode) isByte() bool { return m == Byte }
 // isKanji checks if the mode is Kanji.
 func (m Mode) isKanji() bool { return m == Kanji }
 
+// isHanzi checks if the mode is Hanzi.
+func (m Mode) isHanzi() bool { return m == Hanzi }
+
 // isEci checks if the mode is ECI.
 func (m Mode) isEci() bool { return m == Eci }
 
+// String returns the name of the mode, such as "Numeric" or "ECI".
+func (m Mode) String() string {
+	switch m {
+	case Numeric:
+		return "Numeric"
+	case Alphanumeric:
+		return "Alphanumeric"
+	case Byte:
+		return "Byte"
+	case Kanji:
+		return "Kanji"
+	case Hanzi:
+		return "Hanzi"
+	case Eci:
+		return "ECI"
+	case fnc1First:
+		return "FNC1First"
+	case fnc1Second:
+		return "FNC1Second"
+	case structuredAppend:
+		return "StructuredAppend"
+	}
+	return fmt.Sprintf("Mode(0x%X)", m.modeBits)
+}
+
+// unencodableIn returns the error for the first character of s outside
+// charset, a set of ASCII characters.
+func unencodableIn(s, charset, target string) error {
+	for i, r := range s {
+		if r >= utf8.RuneSelf || strings.IndexByte(charset, byte(r)) < 0 {
+			return &UnencodableCharError{Rune: r, Offset: i, Target: target}
+		}
+	}
+	return fmt.Errorf("%w: empty %s segment", ErrUnencodableChar, target)
+}
+
 // alphanumericCharset lists every character encodable in alphanumeric mode; the
 // index of a character is also its alphanumeric value.
 const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
@@ -96,6 +159,141 @@ func (q *QrSegment) cloneData() *BitBuffer {
 	return q.data.clone()
 }
 
+// Mode returns the mode of the segment.
+func (q *QrSegment) Mode() Mode {
+	return q.mode
+}
+
+// NumChars returns the value of the segment's character count field: digits,
+// characters or bytes depending on the mode, and 0 for ECI, FNC1 and
+// Structured Append.
+func (q *QrSegment) NumChars() int {
+	return q.numChars
+}
+
+// Bits returns a copy of the segment's data bits, without the mode indicator
+// and character count.
+func (q *QrSegment) Bits() []bool {
+	res := make([]bool, q.data.len())
+	for i := range res {
+		res[i] = q.data.getBit(i)
+	}
+	return res
+}
+
+// MakeRawSegment builds a segment from its mode, character count and data
+// bits, such as the values returned by Mode, NumChars and Bits. The bit length
+// must be the one mode gives numChars characters, numeric groups must be below
+// 1000 and alphanumeric pairs below 45*45; ECI, FNC1 and Structured Append
+// data must be well formed and numChars 0. It returns an ErrInvalidArgument
+// error otherwise.
+func MakeRawSegment(mode Mode, numChars int, bits []bool) (*QrSegment, error) {
+	if numChars < 0 {
+		return nil, fmt.Errorf("%w: numChars %d is negative", ErrInvalidArgument, numChars)
+	}
+	bb := &BitBuffer{}
+	for _, bit := range bits {
+		bb.appendBit(bit)
+	}
+	if err := checkRawSegment(mode, numChars, bb); err != nil {
+		return nil, err
+	}
+	return newQrSegment(mode, numChars, bb)
+}
+
+// checkRawSegment validates the data of a MakeRawSegment segment.
+func checkRawSegment(mode Mode, numChars int, bb *BitBuffer) error {
+	n := bb.len()
+	want := -1
+	switch mode {
+	case Numeric:
+		want = numChars/3*10 + [3]int{0, 4, 7}[numChars%3]
+	case Alphanumeric:
+		want = numChars/2*11 + numChars%2*6
+	case Byte:
+		want = numChars * 8
+	case Kanji, Hanzi:
+		want = numChars * 13
+	case Eci:
+		switch {
+		case !bb.getBit(0):
+			want = 8
+		case !bb.getBit(1):
+			want = 16
+		case !bb.getBit(2):
+			want = 24
+		default:
+			return fmt.Errorf("%w: invalid ECI designator prefix", ErrInvalidArgument)
+		}
+	case fnc1First:
+		want = 0
+	case fnc1Second:
+		want = 8
+	case structuredAppend:
+		want = 16
+	default:
+		return fmt.Errorf("%w: unknown mode %v", ErrInvalidArgument, mode)
+	}
+	if cc := mode.numCharCountBits(MaxVersion); cc == 0 && numChars != 0 {
+		return fmt.Errorf("%w: %v segment has no character count, got %d", ErrInvalidArgument, mode, numChars)
+	} else if cc > 0 && numChars >= 1<<cc {
+		return fmt.Errorf("%w: %d characters exceed the %v count field", ErrInvalidArgument, numChars, mode)
+	}
+	if n != want {
+		return fmt.Errorf("%w: %v segment of %d characters needs %d bits, got %d", ErrInvalidArgument, mode, numChars, want, n)
+	}
+
+	read := func(pos, length int) int {
+		v := 0
+		for i := 0; i < length; i++ {
+			v <<= 1
+			if bb.getBit(pos + i) {
+				v |= 1
+			}
+		}
+		return v
+	}
+	switch mode {
+	case Numeric:
+		for pos, left := 0, numChars; left > 0; left -= 3 {
+			width, limit := 10, 1000
+			if left == 2 {
+				width, limit = 7, 100
+			} else if left == 1 {
+				width, limit = 4, 10
+			}
+			if read(pos, width) >= limit {
+				return fmt.Errorf("%w: numeric group at bit %d out of range", ErrInvalidArgument, pos)
+			}
+			pos += width
+		}
+	case Alphanumeric:
+		for pos, left := 0, numChars; left > 0; left -= 2 {
+			width, limit := 11, 45*45
+			if left == 1 {
+				width, limit = 6, 45
+			}
+			if read(pos, width) >= limit {
+				return fmt.Errorf("%w: alphanumeric pair at bit %d out of range", ErrInvalidArgument, pos)
+			}
+			pos += width
+		}
+	case Eci:
+		if n == 24 && read(3, 21) >= 1e6 {
+			return fmt.Errorf("%w: ECI assignment value %d out of range", ErrInvalidArgument, read(3, 21))
+		}
+	case fnc1Second:
+		if v := read(0, 8); v > 99 && !('a' <= v-100 && v-100 <= 'z' || 'A' <= v-100 && v-100 <= 'Z') {
+			return fmt.Errorf("%w: FNC1 application indicator %d out of range", ErrInvalidArgument, v)
+		}
+	case structuredAppend:
+		if read(0, 4) > read(4, 4) {
+			return fmt.Errorf("%w: Structured Append index %d beyond total %d", ErrInvalidArgument, read(0, 4), read(4, 4)+1)
+		}
+	}
+	return nil
+}
+
 // MakeBytes converts a byte slice into a QR segment in Byte mode.
 // It returns an error if the input data is nil.
 func MakeBytes(data []byte) (*QrSegment, error) {
@@ -117,7 +315,7 @@ func MakeBytes(data []byte) (*QrSegment, error) {
 // It returns an error if the string contains non-numeric characters.
 func MakeNumeric(digits string) (*QrSegment, error) {
 	if !isNumeric(digits) {
-		return nil, fmt.Errorf("%w: numeric mode", ErrUnencodableChar)
+		return nil, unencodableIn(digits, "0123456789", "numeric mode")
 	}
 
 	bb := &BitBuffer{}
@@ -162,7 +360,7 @@ func isAlphanumeric(s string) bool {
 // It returns an error if the string contains non-alphanumeric characters.
 func MakeAlphanumeric(text string) (*QrSegment, error) {
 	if !isAlphanumeric(text) {
-		return nil, fmt.Errorf("%w: alphanumeric mode", ErrUnencodableChar)
+		return nil, unencodableIn(text, alphanumericCharset, "alphanumeric mode")
 	}
 
 	bb := &BitBuffer{}
@@ -251,6 +449,42 @@ func MakeEci(val int) (*QrSegment, error) {
 	return newQrSegment(Eci, 0, bb)
 }
 
+// FNC1 mode indicators. Like ECI they carry no character count; FNC1 in
+// second position is followed by an 8-bit application indicator.
+var (
+	fnc1First  = newMode(0x5, 0, 0, 0)
+	fnc1Second = newMode(0x9, 0, 0, 0)
+)
+
+// MakeFnc1First returns the FNC1 first-position segment, which marks the
+// symbol's data as GS1 element strings. It must be the first segment. In the
+// data that follows, alphanumeric segments encode the GS separator as '%' and
+// a literal '%' as "%%"; byte segments use 0x1D. See MakeGS1Segments.
+func MakeFnc1First() (*QrSegment, error) {
+	return newQrSegment(fnc1First, 0, &BitBuffer{})
+}
+
+// MakeFnc1Second returns the FNC1 second-position segment for an industry
+// application identified by appIndicator: either two digits ("00"-"99") or a
+// single ASCII letter, as assigned by AIM. It must be the first segment.
+func MakeFnc1Second(appIndicator string) (*QrSegment, error) {
+	val := -1
+	switch {
+	case len(appIndicator) == 2 && isNumeric(appIndicator):
+		val, _ = strconv.Atoi(appIndicator)
+	case len(appIndicator) == 1 && ('a' <= appIndicator[0] && appIndicator[0] <= 'z' || 'A' <= appIndicator[0] && appIndicator[0] <= 'Z'):
+		val = int(appIndicator[0]) + 100
+	default:
+		return nil, fmt.Errorf("%w: FNC1 application indicator %q is not two digits or one letter", ErrInvalidArgument, appIndicator)
+	}
+
+	bb := &BitBuffer{}
+	if err := bb.appendBits(val, 8); err != nil {
+		return nil, err
+	}
+	return newQrSegment(fnc1Second, 0, bb)
+}
+
 // getTotalBits calculates and returns the total number of bits required to encode the segments at the specified QR version.
 // It returns -1 if the number of characters exceeds the maximum capacity.
 func getTotalBits(segs []*QrSegment, ver int) int {
@@ -264,7 +498,7 @@ func getTotalBits(segs []*QrSegment, ver int) int {
 		if seg.numChars >= (1 << ccbits) {
 			return -1
 		}
-		res += int64(4 + ccbits + seg.data.len())
+		res += int64(seg.mode.headerBits(ver) + seg.data.len())
 		if res > math.MaxInt32 {
 			return -1
 		}
@@ -2,9 +2,11 @@ package go_qr
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"reflect"
 	"unicode/utf16"
+	"unicode/utf8"
 )
 
 // MakeSegmentsOptimally takes a string and error correction level, and attempts to
@@ -21,35 +23,141 @@ func MakeSegmentsOptimally(text string, ecl Ecc, minVersion, maxVersion int) ([]
 	if err != nil {
 		return nil, err
 	}
+	return makeSegmentsOptimallyCharset(codePoints, ecl, minVersion, maxVersion, CharsetUTF8, nil, false)
+}
+
+// MakeSegmentsOptimallyHanzi is MakeSegmentsOptimally with Hanzi mode (GB/T
+// 18284) among the candidate modes, so runs of GB 2312 Chinese outside Kanji
+// mode take 13 bits per character. Hanzi mode is a Chinese national
+// extension that not every reader supports, so MakeSegmentsOptimally leaves
+// it out.
+func MakeSegmentsOptimallyHanzi(text string, ecl Ecc, minVersion, maxVersion int) ([]*QrSegment, error) {
+	if !isValidVersion(minVersion, maxVersion) {
+		return nil, fmt.Errorf("%w: minVersion=%d maxVersion=%d", ErrInvalidVersion, minVersion, maxVersion)
+	}
+
+	codePoints, err := toCodePoints(text)
+	if err != nil {
+		return nil, err
+	}
+	return makeSegmentsOptimallyCharset(codePoints, ecl, minVersion, maxVersion, CharsetUTF8, nil, true)
+}
+
+// MakeBinarySegmentsOptimally is MakeSegmentsOptimally for arbitrary bytes,
+// which need not be valid UTF-8. Digit and upper-case alphanumeric runs move
+// to Numeric and Alphanumeric segments where that saves bits, by the same
+// cost model; every other byte stays in Byte mode unchanged, so a reader
+// gets data back verbatim.
+func MakeBinarySegmentsOptimally(data []byte, ecl Ecc, minVersion, maxVersion int) ([]*QrSegment, error) {
+	if !isValidVersion(minVersion, maxVersion) {
+		return nil, fmt.Errorf("%w: minVersion=%d maxVersion=%d", ErrInvalidVersion, minVersion, maxVersion)
+	}
+	if len(data) > 7089 {
+		return nil, fmt.Errorf("%w: data exceeds 7089 bytes", ErrDataTooLong)
+	}
+
+	return fitOptimalSegments(ecl, minVersion, maxVersion, func(version int) ([]*QrSegment, error) {
+		return makeBinarySegmentsWithVersion(data, version)
+	})
+}
+
+// makeBinarySegmentsWithVersion segments data optimally for the
+// character-count widths of version.
+func makeBinarySegmentsWithVersion(data []byte, version int) ([]*QrSegment, error) {
+	if len(data) == 0 {
+		return []*QrSegment{}, nil
+	}
+	values := make([]int, len(data))
+	for i, b := range data {
+		values[i] = int(b)
+	}
+	oneByte := func(int) (int, error) { return 1, nil }
+	modes, err := computeModes(values, version, []Mode{Byte, Alphanumeric, Numeric}, oneByte)
+	if err != nil {
+		return nil, err
+	}
+	return splitBinaryIntoSegments(data, modes)
+}
 
+// splitBinaryIntoSegments is splitIntoSegments for raw bytes.
+func splitBinaryIntoSegments(data []byte, modes []Mode) ([]*QrSegment, error) {
+	res := make([]*QrSegment, 0)
+	start := 0
+	for i := 1; i <= len(data); i++ {
+		if i < len(data) && modes[i] == modes[start] {
+			continue
+		}
+		var seg *QrSegment
+		var err error
+		switch run := data[start:i]; {
+		case modes[start].isNumeric():
+			seg, err = MakeNumeric(string(run))
+		case modes[start].isAlphanumeric():
+			seg, err = MakeAlphanumeric(string(run))
+		default:
+			seg, err = MakeBytes(run)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, seg)
+		start = i
+	}
+	return res, nil
+}
+
+// makeSegmentsOptimallyCharset is the body of MakeSegmentsOptimally with byte
+// runs transcoded into cs. A non-nil eci segment is prepended to the result
+// and counted against the capacity. hanzi adds Hanzi mode to the candidates.
+func makeSegmentsOptimallyCharset(codePoints []int, ecl Ecc, minVersion, maxVersion int, cs Charset, eci *QrSegment, hanzi bool) ([]*QrSegment, error) {
+	return fitOptimalSegments(ecl, minVersion, maxVersion, func(version int) ([]*QrSegment, error) {
+		charModes, err := computeCharacterModesCharset(codePoints, version, cs, hanzi)
+		if err != nil {
+			return nil, err
+		}
+		segs, err := splitIntoSegmentsCharset(codePoints, charModes, cs)
+		if err != nil {
+			return nil, err
+		}
+		if eci != nil {
+			segs = append([]*QrSegment{eci}, segs...)
+		}
+		return segs, nil
+	})
+}
+
+// fitOptimalSegments returns the segments build makes for the smallest
+// version in [minVersion, maxVersion] that holds them at ecl.
+func fitOptimalSegments(ecl Ecc, minVersion, maxVersion int, build func(version int) ([]*QrSegment, error)) ([]*QrSegment, error) {
+	// Segments only change when the character-count widths do (versions 10
+	// and 27); capacity is checked at every version in between.
+	var segs []*QrSegment
 	for version := minVersion; ; version++ {
 		if version == minVersion || version == 10 || version == 27 {
-			segs, err := makeSegmentsOptimallyWithVersion(codePoints, version)
-			if err != nil {
+			var err error
+			if segs, err = build(version); err != nil {
 				return nil, err
 			}
+		}
 
-			dataCapacityBits := getNumDataCodewords(version, ecl) * 8
-			dataUsedBits := getTotalBits(segs, version)
-			if dataUsedBits != -1 && dataUsedBits <= dataCapacityBits {
-				return segs, nil
-			}
-			if version >= maxVersion {
-				if dataUsedBits != -1 {
-					return nil, fmt.Errorf("%w: data length %d bits exceeds capacity %d bits", ErrDataTooLong, dataUsedBits, dataCapacityBits)
-				}
-				return nil, fmt.Errorf("%w: segment too long", ErrDataTooLong)
-			}
+		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+		dataUsedBits := getTotalBits(segs, version)
+		if dataUsedBits != -1 && dataUsedBits <= dataCapacityBits {
+			return segs, nil
+		}
+		if version >= maxVersion {
+			return nil, &DataTooLongError{
+				RequiredBits: dataUsedBits, AvailableBits: dataCapacityBits, Version: version, Ecc: ecl}
 		}
 	}
 }
 
 // makeSegmentsOptimallyWithVersion takes code points and a version number,
 // computes the character modes suitable for that version, and then splits the
-// code points into segments accordingly. Returns an array of pointers to
-// QrSegment or an error.
-func makeSegmentsOptimallyWithVersion(codePoints []int, version int) ([]*QrSegment, error) {
-	charModes, err := computeCharacterModes(codePoints, version)
+// code points into segments accordingly. hanzi adds Hanzi mode to the
+// candidates. Returns an array of pointers to QrSegment or an error.
+func makeSegmentsOptimallyWithVersion(codePoints []int, version int, hanzi bool) ([]*QrSegment, error) {
+	charModes, err := computeCharacterModesCharset(codePoints, version, CharsetUTF8, hanzi)
 	if err != nil {
 		return nil, err
 	}
@@ -88,18 +196,55 @@ func countUtf8Bytes(cp int) (int, error) {
 	}
 }
 
-// computeCharacterModes determines the optimal encoding mode for each character in the input string.
-func computeCharacterModes(codePoints []int, version int) ([]Mode, error) {
+// utf8Offset returns the UTF-8 length of codePoints, the byte offset in the
+// input string of the code point that follows them.
+func utf8Offset(codePoints []int) int {
+	n := 0
+	for _, cp := range codePoints {
+		n += utf8.RuneLen(rune(cp))
+	}
+	return n
+}
+
+// computeCharacterModesCharset determines the optimal encoding mode for each
+// character in the input string, with byte-mode costs taken from the length
+// of each character in cs. Hanzi mode is a candidate only when hanzi is set.
+func computeCharacterModesCharset(codePoints []int, version int, cs Charset, hanzi bool) ([]Mode, error) {
 	if len(codePoints) > 7089 {
 		return nil, fmt.Errorf("%w: string exceeds 7089 code points", ErrDataTooLong)
 	}
+	var scratch [4]byte
+	byteLen := func(c int) (int, error) {
+		count, err := countUtf8Bytes(c)
+		if err != nil {
+			return 0, err
+		}
+		if cs != CharsetUTF8 {
+			encoded, ok := cs.appendRune(scratch[:0], rune(c))
+			if !ok {
+				return 0, &UnencodableCharError{Rune: rune(c), Target: cs.String()}
+			}
+			count = len(encoded)
+		}
+		return count, nil
+	}
 	modeTypes := []Mode{Byte, Alphanumeric, Numeric, Kanji}
+	if hanzi {
+		modeTypes = append(modeTypes, Hanzi)
+	}
+	return computeModes(codePoints, version, modeTypes, byteLen)
+}
+
+// computeModes is the dynamic program behind computeCharacterModesCharset.
+// Costs are in sixths of a bit; modeTypes must start with Byte, and byteLen
+// gives the byte-mode length of a character.
+func computeModes(codePoints []int, version int, modeTypes []Mode, byteLen func(c int) (int, error)) ([]Mode, error) {
 	numModes := len(modeTypes)
 
 	headCosts := make([]int, numModes)
 	charModes := make([][]Mode, len(codePoints))
 	for i := 0; i < numModes; i++ {
-		headCosts[i] = (4 + modeTypes[i].numCharCountBits(version)) * 6
+		headCosts[i] = modeTypes[i].headerBits(version) * 6
 	}
 
 	for i := range charModes {
@@ -113,28 +258,30 @@ func computeCharacterModes(codePoints []int, version int) ([]Mode, error) {
 	for i := 0; i < len(codePoints); i++ {
 		c := codePoints[i]
 		curCosts := make([]int, numModes)
-		{
-			count, err := countUtf8Bytes(c)
-			if err != nil {
-				return nil, err
+		for j, m := range modeTypes {
+			var cost int
+			switch {
+			case m.isByte():
+				count, err := byteLen(c)
+				if err != nil {
+					var ue *UnencodableCharError
+					if errors.As(err, &ue) {
+						ue.Offset = utf8Offset(codePoints[:i])
+					}
+					return nil, err
+				}
+				cost = count * 8 * 6
+			case m.isAlphanumeric() && isAlphanumeric(string(rune(c))):
+				cost = 33
+			case m.isNumeric() && isNumeric(string(rune(c))):
+				cost = 20
+			case m.isKanji() && isKanji(c), m.isHanzi() && isHanzi(c):
+				cost = 78
+			default:
+				continue
 			}
-			curCosts[0] = prevCosts[0] + count*8*6
-			charModes[i][0] = modeTypes[0]
-		}
-
-		if isAlphanumeric(string(rune(c))) {
-			curCosts[1] = prevCosts[1] + 33
-			charModes[i][1] = modeTypes[1]
-		}
-
-		if isNumeric(string(rune(c))) {
-			curCosts[2] = prevCosts[2] + 20
-			charModes[i][2] = modeTypes[2]
-		}
-
-		if isKanji(c) {
-			curCosts[3] = prevCosts[3] + 78
-			charModes[i][3] = modeTypes[3]
+			curCosts[j] = prevCosts[j] + cost
+			charModes[i][j] = m
 		}
 
 		for j := 0; j < numModes; j++ {
@@ -174,7 +321,16 @@ func computeCharacterModes(codePoints []int, version int) ([]Mode, error) {
 // splitIntoSegments is used to splits the input into multiple QR segments according to the given modes.
 // Each change in mode results in a new segment being created.
 func splitIntoSegments(codePoints []int, charModes []Mode) ([]*QrSegment, error) {
+	return splitIntoSegmentsCharset(codePoints, charModes, CharsetUTF8)
+}
+
+// splitIntoSegmentsCharset is splitIntoSegments with byte segments transcoded
+// into cs.
+func splitIntoSegmentsCharset(codePoints []int, charModes []Mode, cs Charset) ([]*QrSegment, error) {
 	res := make([]*QrSegment, 0)
+	if len(codePoints) == 0 {
+		return res, nil
+	}
 	curMode := charModes[0]
 	start := 0
 	for i := 1; ; i++ {
@@ -191,7 +347,11 @@ func splitIntoSegments(codePoints []int, charModes []Mode) ([]*QrSegment, error)
 
 		// Create a QR segment based on the current mode
 		if curMode.isByte() {
-			qs, err := MakeBytes([]byte(s))
+			data, err := cs.Encode(s)
+			if err != nil {
+				return nil, err
+			}
+			qs, err := MakeBytes(data)
 			if err != nil {
 				return nil, err
 			}
@@ -214,6 +374,12 @@ func splitIntoSegments(codePoints []int, charModes []Mode) ([]*QrSegment, error)
 				return nil, err
 			}
 			res = append(res, qs)
+		} else if curMode.isHanzi() {
+			qs, err := MakeHanzi(s)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, qs)
 		} else {
 			return nil, fmt.Errorf("%w: unknown segment mode", ErrInvalidArgument)
 		}
@@ -230,9 +396,9 @@ func splitIntoSegments(codePoints []int, charModes []Mode) ([]*QrSegment, error)
 func MakeKanji(text string) (*QrSegment, error) {
 	bb := &BitBuffer{}
 	runes := []rune(text)
-	for _, c := range text {
+	for i, c := range text {
 		if !isKanji(int(c)) {
-			return nil, fmt.Errorf("%w: kanji mode", ErrUnencodableChar)
+			return nil, &UnencodableCharError{Rune: c, Offset: i, Target: "kanji mode"}
 		}
 		val := unicdeToQRKanji[c]
 		err := bb.appendBits(val, 13)
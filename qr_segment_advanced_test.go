@@ -1,8 +1,10 @@
 package go_qr
 
 import (
-	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMakeSegmentsOptimally(t *testing.T) {
@@ -216,3 +218,58 @@ func TestCountUtf8Bytes(t *testing.T) {
 		})
 	}
 }
+
+// TestMakeSegmentsOptimallyMidClassVersion covers data that fits maxVersion
+// but not the first version of its character-count class (27 here).
+func TestMakeSegmentsOptimallyMidClassVersion(t *testing.T) {
+	segs, err := MakeSegmentsOptimally(strings.Repeat("a", 1700), Low, 1, 35)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, getTotalBits(segs, 35), getNumDataCodewords(35, Low)*8)
+
+	_, err = MakeSegmentsOptimally(strings.Repeat("a", 1700), Low, 1, 28)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestMakeBinarySegmentsOptimally(t *testing.T) {
+	data := append([]byte{0x00, 0xFF, 0xC3, 0x28}, []byte("0123456789012345678901234567890ABCDEFGHIJKLMNOP:")...)
+	data = append(data, 0x80, 'a')
+
+	segs, err := MakeBinarySegmentsOptimally(data, Low, 1, 40)
+	assert.NoError(t, err)
+	modes := make([]Mode, len(segs))
+	for i, seg := range segs {
+		modes[i] = seg.mode
+	}
+	assert.Equal(t, []Mode{Byte, Numeric, Alphanumeric, Byte}, modes)
+	assert.Equal(t, 4, segs[0].numChars)
+	assert.Equal(t, 31, segs[1].numChars)
+
+	single, err := MakeBytes(data)
+	assert.NoError(t, err)
+	assert.Less(t, getTotalBits(segs, 1), getTotalBits([]*QrSegment{single}, 1))
+
+	// Short runs do not pay for a mode switch, and no byte is ever
+	// reinterpreted as Kanji (0xA7 is U+00A7 in Shift_JIS).
+	segs, err = MakeBinarySegmentsOptimally([]byte{0xA7, '1', '2', 0xB0}, Low, 1, 40)
+	assert.NoError(t, err)
+	assert.Len(t, segs, 1)
+	assert.Equal(t, Byte, segs[0].mode)
+
+	segs, err = MakeBinarySegmentsOptimally(nil, Low, 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, segs)
+
+	_, err = MakeBinarySegmentsOptimally(data, Low, 2, 1)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+	_, err = MakeBinarySegmentsOptimally(make([]byte, 200), High, 1, 5)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+
+	// The symbol decodes to the original bytes.
+	qr, err := Encode(string(data), WithBinaryInput(), WithSegmentation(SegmentationOptimal))
+	assert.NoError(t, err)
+	plain, err := EncodeBinary(data, Low)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, qr.version, plain.version)
+	res := decodeSymbol(t, qr)
+	assert.Equal(t, string(data), res.Text)
+}
@@ -559,3 +559,83 @@ func TestQrSegment_GetData(t *testing.T) {
 		})
 	}
 }
+
+func TestQrSegmentAccessors(t *testing.T) {
+	segs, err := MakeSegmentsOptimally("ABCDEFGH 0123456789012345 abc 点茗", Low, MinVersion, MaxVersion)
+	assert.NoError(t, err)
+	extra := []func() (*QrSegment, error){
+		func() (*QrSegment, error) { return MakeEci(26) },
+		func() (*QrSegment, error) { return MakeEci(999999) },
+		func() (*QrSegment, error) { return MakeFnc1First() },
+		func() (*QrSegment, error) { return MakeFnc1Second("z") },
+		func() (*QrSegment, error) { return MakeStructuredAppend(2, 3, 0x5A) },
+		func() (*QrSegment, error) { return MakeHanzi("啊") },
+		func() (*QrSegment, error) { return MakeNumeric("12") },
+		func() (*QrSegment, error) { return MakeAlphanumeric("A") },
+	}
+	for _, f := range extra {
+		seg, err := f()
+		assert.NoError(t, err)
+		segs = append(segs, seg)
+	}
+
+	// Every segment round-trips through MakeRawSegment.
+	for _, seg := range segs {
+		assert.Equal(t, seg.data.len(), len(seg.Bits()))
+		raw, err := MakeRawSegment(seg.Mode(), seg.NumChars(), seg.Bits())
+		assert.NoError(t, err, "%v", seg.Mode())
+		assert.Equal(t, seg, raw)
+	}
+
+	// Bits returns a copy.
+	bits := segs[0].Bits()
+	bits[0] = !bits[0]
+	assert.NotEqual(t, bits, segs[0].Bits())
+}
+
+func TestModeString(t *testing.T) {
+	assert.Equal(t, "Numeric", Numeric.String())
+	assert.Equal(t, "Alphanumeric", Alphanumeric.String())
+	assert.Equal(t, "Byte", Byte.String())
+	assert.Equal(t, "Kanji", Kanji.String())
+	assert.Equal(t, "Hanzi", Hanzi.String())
+	assert.Equal(t, "ECI", Eci.String())
+	assert.Equal(t, "FNC1First", fnc1First.String())
+	assert.Equal(t, "StructuredAppend", structuredAppend.String())
+	assert.Equal(t, "Mode(0xE)", newMode(0xE, 0, 0, 0).String())
+}
+
+func TestMakeRawSegmentErrors(t *testing.T) {
+	bits := func(s string) []bool {
+		res := make([]bool, len(s))
+		for i := range s {
+			res[i] = s[i] == '1'
+		}
+		return res
+	}
+	tests := []struct {
+		name     string
+		mode     Mode
+		numChars int
+		bits     []bool
+	}{
+		{"negative count", Byte, -1, nil},
+		{"unknown mode", newMode(0xE, 8, 16, 16), 0, nil},
+		{"short byte data", Byte, 2, bits("01000001")},
+		{"numeric group 1000", Numeric, 3, bits("1111101000")},
+		{"numeric digit 10", Numeric, 1, bits("1010")},
+		{"alphanumeric char 45", Alphanumeric, 1, bits("101101")},
+		{"count too large", Byte, 1 << 16, make([]bool, 8<<16)},
+		{"eci with count", Eci, 1, bits("00011010")},
+		{"eci bad prefix", Eci, 0, bits("11100000")},
+		{"eci value too large", Eci, 0, bits("110111111111111111111111")},
+		{"fnc1 second bad indicator", fnc1Second, 0, bits("11111111")},
+		{"structured append index", structuredAppend, 0, bits("0011000100000000")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MakeRawSegment(tt.mode, tt.numChars, tt.bits)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
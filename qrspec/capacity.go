@@ -0,0 +1,77 @@
+package qrspec
+
+import "fmt"
+
+// Mode is a data encoding mode with a per-character bit cost.
+type Mode int
+
+const (
+	Numeric Mode = iota
+	Alphanumeric
+	Byte
+	Kanji
+)
+
+// modeIndicators are the 4-bit mode indicators of each Mode.
+var modeIndicators = [...]int{0x1, 0x2, 0x4, 0x8}
+
+// charCountBits is the width of the character count field of each Mode for
+// versions 1-9, 10-26 and 27-40.
+var charCountBits = [...][3]int{
+	Numeric:      {10, 12, 14},
+	Alphanumeric: {9, 11, 13},
+	Byte:         {8, 16, 16},
+	Kanji:        {8, 10, 12},
+}
+
+// Indicator returns the 4-bit mode indicator. It panics if m is not one of
+// the Mode constants.
+func (m Mode) Indicator() int {
+	m.check()
+	return modeIndicators[m]
+}
+
+// check panics unless m is one of the Mode constants.
+func (m Mode) check() {
+	if m < Numeric || m > Kanji {
+		panic(fmt.Sprintf("qrspec: invalid mode %d", int(m)))
+	}
+}
+
+// CharCountBits returns the width of the character count field of mode in a
+// symbol of the given version. It panics if mode is invalid or version is
+// outside [MinVersion, MaxVersion].
+func CharCountBits(mode Mode, version int) int {
+	mode.check()
+	checkVersion(version)
+	return charCountBits[mode][(version+7)/17]
+}
+
+// CharacterCapacity returns how many characters of mode fit a version at
+// level in a single segment, as tabulated in ISO/IEC 18004 Table 7. It panics
+// if version is outside [MinVersion, MaxVersion] or level or mode is invalid.
+func CharacterCapacity(version int, level Level, mode Mode) int {
+	ccBits := CharCountBits(mode, version)
+	bits := DataCodewords(version, level)*8 - 4 - ccBits
+
+	var n int
+	switch mode {
+	case Numeric:
+		n = bits / 10 * 3
+		if rem := bits % 10; rem >= 7 {
+			n += 2
+		} else if rem >= 4 {
+			n++
+		}
+	case Alphanumeric:
+		n = bits / 11 * 2
+		if bits%11 >= 6 {
+			n++
+		}
+	case Byte:
+		n = bits / 8
+	case Kanji:
+		n = bits / 13
+	}
+	return min(n, 1<<ccBits-1)
+}
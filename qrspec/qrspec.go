@@ -0,0 +1,274 @@
+// Package qrspec publishes the QR Code Model 2 symbol tables of ISO/IEC 18004:
+// block structure per version and error correction level, alignment pattern
+// positions, version and format information bits, and character capacities.
+//
+// It is the single source of truth for the go_qr encoder and decoder. Every
+// function is pure and every returned slice is a fresh copy, so callers may
+// not alter the tables. Arguments are not checked for errors: a version
+// outside [MinVersion, MaxVersion] or an unknown Level or Mode is a
+// programming mistake, and the functions taking one panic on it.
+package qrspec
+
+import "fmt"
+
+// MinVersion / MaxVersion bound the QR Code Model 2 versions.
+const (
+	MinVersion = 1
+	MaxVersion = 40
+)
+
+// Level is an error correction level, in the same order as go_qr.Ecc.
+type Level int
+
+const (
+	L Level = iota // 7% of codewords can be restored
+	M              // 15% of codewords can be restored
+	Q              // 25% of codewords can be restored
+	H              // 30% of codewords can be restored
+)
+
+// levelFormatBits maps each Level to its 2-bit format information value.
+var levelFormatBits = [...]int{1, 0, 3, 2}
+
+// FormatBits returns the 2-bit value that identifies the level in the format
+// information. It panics if l is not L, M, Q or H.
+func (l Level) FormatBits() int {
+	l.check()
+	return levelFormatBits[l]
+}
+
+// check panics unless l is one of L, M, Q and H.
+func (l Level) check() {
+	if l < L || l > H {
+		panic(fmt.Sprintf("qrspec: invalid level %d", int(l)))
+	}
+}
+
+// LevelFromFormatBits is the inverse of Level.FormatBits.
+func LevelFromFormatBits(bits int) Level {
+	// The mapping is an involution.
+	return Level(levelFormatBits[bits&3])
+}
+
+// ValidVersion reports whether version lies in [MinVersion, MaxVersion].
+func ValidVersion(version int) bool {
+	return MinVersion <= version && version <= MaxVersion
+}
+
+// checkVersion panics unless version lies in [MinVersion, MaxVersion].
+func checkVersion(version int) {
+	if !ValidVersion(version) {
+		panic(fmt.Sprintf("qrspec: version %d outside [%d, %d]", version, MinVersion, MaxVersion))
+	}
+}
+
+// Size returns the side length in modules of a symbol of the given version.
+// It panics if version is outside [MinVersion, MaxVersion].
+func Size(version int) int {
+	checkVersion(version)
+	return version*4 + 17
+}
+
+// eccCodewordsPerBlock is the number of error correction codewords per block,
+// indexed by level and version.
+var eccCodewordsPerBlock = [4][41]int8{
+	// Version: (note that index 0 is for padding, and is set to an illegal value)
+	//0,  1,  2,  3,  4,  5,  6,  7,  8,  9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40    Error correction level
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},  // Low
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}, // Medium
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // Quartile
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // High
+}
+
+// numErrorCorrectionBlocks is the number of error correction blocks, indexed
+// by level and version.
+var numErrorCorrectionBlocks = [4][41]int8{
+	// Version: (note that index 0 is for padding, and is set to an illegal value)
+	//0, 1, 2, 3, 4, 5, 6, 7, 8, 9,10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40    Error correction level
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},              // Low
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},     // Medium
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},  // Quartile
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81}, // High
+}
+
+// Blocks is the Reed-Solomon block structure of one version and level. The
+// short blocks come first; each long block carries one more data codeword.
+type Blocks struct {
+	NumBlocks               int
+	NumShortBlocks          int
+	ECCodewordsPerBlock     int
+	ShortBlockDataCodewords int
+}
+
+// NumLongBlocks returns the number of blocks with an extra data codeword.
+func (b Blocks) NumLongBlocks() int {
+	return b.NumBlocks - b.NumShortBlocks
+}
+
+// DataCodewords returns the data codewords of block i.
+func (b Blocks) DataCodewords(i int) int {
+	if i < b.NumShortBlocks {
+		return b.ShortBlockDataCodewords
+	}
+	return b.ShortBlockDataCodewords + 1
+}
+
+// TotalDataCodewords returns the data codewords over all blocks.
+func (b Blocks) TotalDataCodewords() int {
+	return b.NumBlocks*b.ShortBlockDataCodewords + b.NumLongBlocks()
+}
+
+// CodewordBlock returns the block that codeword i of the final, interleaved
+// sequence belongs to. Data codewords are interleaved round-robin (long blocks
+// alone supply the last one), followed by the ECC codewords round-robin.
+func (b Blocks) CodewordBlock(i int) int {
+	data := b.TotalDataCodewords()
+	if i >= data {
+		return (i - data) % b.NumBlocks
+	}
+	if i < b.ShortBlockDataCodewords*b.NumBlocks {
+		return i % b.NumBlocks
+	}
+	return b.NumShortBlocks + i - b.ShortBlockDataCodewords*b.NumBlocks
+}
+
+// CodewordIndex returns the position of codeword i of the final, interleaved
+// sequence within its block (see CodewordBlock), data codewords first.
+func (b Blocks) CodewordIndex(i int) int {
+	data := b.TotalDataCodewords()
+	if i >= data {
+		return b.DataCodewords(b.CodewordBlock(i)) + (i-data)/b.NumBlocks
+	}
+	if i < b.ShortBlockDataCodewords*b.NumBlocks {
+		return i / b.NumBlocks
+	}
+	return b.ShortBlockDataCodewords
+}
+
+// DataOffset returns the index of the first data codeword of block j in the
+// data codewords before interleaving.
+func (b Blocks) DataOffset(j int) int {
+	return j*b.ShortBlockDataCodewords + max(0, j-b.NumShortBlocks)
+}
+
+// BlockStructure returns the block structure of version at level. It panics
+// if version is outside [MinVersion, MaxVersion] or level is invalid.
+func BlockStructure(version int, level Level) Blocks {
+	checkVersion(version)
+	level.check()
+	numBlocks := int(numErrorCorrectionBlocks[level][version])
+	eccLen := int(eccCodewordsPerBlock[level][version])
+	total := TotalCodewords(version)
+	return Blocks{
+		NumBlocks:               numBlocks,
+		NumShortBlocks:          numBlocks - total%numBlocks,
+		ECCodewordsPerBlock:     eccLen,
+		ShortBlockDataCodewords: total/numBlocks - eccLen,
+	}
+}
+
+// RawDataModules returns the number of modules available for data and error
+// correction codewords, remainder bits included. It panics if version is
+// outside [MinVersion, MaxVersion].
+func RawDataModules(version int) int {
+	// Total modules in the size×size grid.
+	size := Size(version)
+	res := size * size
+
+	// Subtract the three 8×8 finder-pattern regions (incl. separators).
+	res -= 8 * 8 * 3
+
+	// Subtract the two timing patterns (15 modules each) and the dark module.
+	res -= 15*2 + 1
+
+	// Subtract the timing-pattern border modules.
+	res -= (size - 16) * 2
+
+	// Alignment patterns and version info exist from version 2 / 7 up.
+	if version >= 2 {
+		numAlign := version/7 + 2
+		res -= (numAlign - 1) * (numAlign - 1) * 25
+		res -= (numAlign - 2) * 2 * 20
+		if version >= 7 {
+			res -= 6 * 3 * 2
+		}
+	}
+	return res
+}
+
+// TotalCodewords returns the number of data plus error correction codewords.
+// It panics if version is outside [MinVersion, MaxVersion].
+func TotalCodewords(version int) int {
+	return RawDataModules(version) / 8
+}
+
+// DataCodewords returns the number of data codewords of version at level. It
+// panics if version is outside [MinVersion, MaxVersion] or level is invalid.
+func DataCodewords(version int, level Level) int {
+	checkVersion(version)
+	level.check()
+	return TotalCodewords(version) -
+		int(eccCodewordsPerBlock[level][version])*int(numErrorCorrectionBlocks[level][version])
+}
+
+// AlignmentPatternPositions returns the alignment pattern center coordinates
+// along either axis. For version 1 the result is empty. It panics if version
+// is outside [MinVersion, MaxVersion].
+func AlignmentPatternPositions(version int) []int {
+	checkVersion(version)
+	if version == 1 {
+		return []int{}
+	}
+	numAlign := version/7 + 2
+	step := 0
+	if version == 32 {
+		step = 26
+	} else {
+		step = (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+
+	res := make([]int, numAlign)
+	res[0] = 6
+	for i, pos := len(res)-1, Size(version)-7; i >= 1; {
+		res[i] = pos
+		i--
+		pos -= step
+	}
+	return res
+}
+
+// VersionInfoBits returns the 18-bit version information (6-bit version and
+// BCH(18,6) remainder) drawn from version 7 up, or 0 below that. It panics
+// if version is outside [MinVersion, MaxVersion].
+func VersionInfoBits(version int) int {
+	checkVersion(version)
+	if version < 7 {
+		return 0
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// FormatInfoMask is XORed into the format information so that it is never
+// all zero.
+const FormatInfoMask = 0x5412
+
+// FormatBCH returns the unmasked 15-bit BCH(15,5) codeword of a 5-bit format
+// data value (level format bits << 3 | mask).
+func FormatBCH(data int) int {
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return data<<10 | rem
+}
+
+// FormatInfoBits returns the 15-bit format information for level and mask
+// (0-7) as drawn in the symbol, FormatInfoMask applied. It panics if level is
+// invalid.
+func FormatInfoBits(level Level, mask int) int {
+	return FormatBCH(level.FormatBits()<<3|mask) ^ FormatInfoMask
+}
@@ -0,0 +1,121 @@
+package qrspec
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStructure(t *testing.T) {
+	// 5-Q: two blocks of 15 data codewords and two of 16, 18 ECC each.
+	b := BlockStructure(5, Q)
+	assert.Equal(t, Blocks{NumBlocks: 4, NumShortBlocks: 2, ECCodewordsPerBlock: 18, ShortBlockDataCodewords: 15}, b)
+	assert.Equal(t, 2, b.NumLongBlocks())
+	assert.Equal(t, 15, b.DataCodewords(1))
+	assert.Equal(t, 16, b.DataCodewords(2))
+	assert.Equal(t, 62, b.TotalDataCodewords())
+
+	// Interleaved order: 15 rounds over all four blocks, one over the long
+	// blocks, then the ECC codewords.
+	assert.Equal(t, 3, b.CodewordBlock(3))
+	assert.Equal(t, 1, b.CodewordBlock(57))
+	assert.Equal(t, 2, b.CodewordBlock(60))
+	assert.Equal(t, 3, b.CodewordBlock(61))
+	assert.Equal(t, 0, b.CodewordBlock(62))
+	assert.Equal(t, 3, b.CodewordBlock(62+18*4-1))
+	assert.Equal(t, 0, b.CodewordIndex(3))
+	assert.Equal(t, 14, b.CodewordIndex(57))
+	assert.Equal(t, 15, b.CodewordIndex(61))
+	assert.Equal(t, 15, b.CodewordIndex(62))
+	assert.Equal(t, 16+17, b.CodewordIndex(62+18*4-1))
+	assert.Equal(t, []int{0, 15, 30, 46}, []int{b.DataOffset(0), b.DataOffset(1), b.DataOffset(2), b.DataOffset(3)})
+
+	for v := MinVersion; v <= MaxVersion; v++ {
+		for l := L; l <= H; l++ {
+			b := BlockStructure(v, l)
+			assert.Equal(t, DataCodewords(v, l), b.TotalDataCodewords(), "version %d level %d", v, l)
+			assert.Equal(t, TotalCodewords(v), b.TotalDataCodewords()+b.NumBlocks*b.ECCodewordsPerBlock)
+		}
+	}
+}
+
+func TestDataCodewords(t *testing.T) {
+	assert.Equal(t, 26, TotalCodewords(1))
+	assert.Equal(t, 3706, TotalCodewords(40))
+	assert.Equal(t, 9, DataCodewords(1, H))
+	assert.Equal(t, 2956, DataCodewords(40, L))
+	assert.Equal(t, 208, RawDataModules(1))
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	assert.Empty(t, AlignmentPatternPositions(1))
+	assert.Equal(t, []int{6, 18}, AlignmentPatternPositions(2))
+	assert.Equal(t, []int{6, 22, 38}, AlignmentPatternPositions(7))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, AlignmentPatternPositions(32))
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, AlignmentPatternPositions(40))
+
+	// Callers get a copy.
+	AlignmentPatternPositions(7)[0] = 0
+	assert.Equal(t, 6, AlignmentPatternPositions(7)[0])
+}
+
+func TestVersionAndFormatInfo(t *testing.T) {
+	assert.Equal(t, 0, VersionInfoBits(6))
+	assert.Equal(t, 0x07C94, VersionInfoBits(7))
+	assert.Equal(t, 0x28C69, VersionInfoBits(40))
+
+	assert.Equal(t, 0x5412, FormatInfoBits(M, 0))
+	assert.Equal(t, 0b110011000101111, FormatInfoBits(L, 4))
+	for l := L; l <= H; l++ {
+		assert.Equal(t, l, LevelFromFormatBits(l.FormatBits()))
+	}
+}
+
+func TestCharacterCapacity(t *testing.T) {
+	tests := []struct {
+		version int
+		level   Level
+		mode    Mode
+		want    int
+	}{
+		{1, L, Numeric, 41},
+		{1, L, Alphanumeric, 25},
+		{1, L, Byte, 17},
+		{1, L, Kanji, 10},
+		{1, H, Numeric, 17},
+		{10, M, Byte, 213},
+		{40, L, Numeric, 7089},
+		{40, L, Alphanumeric, 4296},
+		{40, L, Byte, 2953},
+		{40, L, Kanji, 1817},
+		{40, H, Byte, 1273},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CharacterCapacity(tt.version, tt.level, tt.mode), "%d-%d mode %d", tt.version, tt.level, tt.mode)
+	}
+	assert.Equal(t, 16, CharCountBits(Byte, 10))
+	assert.Equal(t, 0x4, Byte.Indicator())
+}
+
+func TestInvalidArgumentsPanic(t *testing.T) {
+	for _, version := range []int{0, 41} {
+		assert.PanicsWithValue(t, "qrspec: version "+strconv.Itoa(version)+" outside [1, 40]", func() { Size(version) })
+		msg := "qrspec: version " + strconv.Itoa(version) + " outside [1, 40]"
+		assert.PanicsWithValue(t, msg, func() { BlockStructure(version, L) })
+		assert.PanicsWithValue(t, msg, func() { BlockStructure(version, Level(9)) })
+		assert.Panics(t, func() { TotalCodewords(version) })
+		assert.PanicsWithValue(t, msg, func() { DataCodewords(version, L) })
+		assert.Panics(t, func() { AlignmentPatternPositions(version) })
+		assert.Panics(t, func() { VersionInfoBits(version) })
+		assert.Panics(t, func() { CharacterCapacity(version, L, Byte) })
+	}
+	for _, level := range []Level{-1, 4} {
+		assert.PanicsWithValue(t, "qrspec: invalid level "+strconv.Itoa(int(level)), func() { level.FormatBits() })
+		assert.Panics(t, func() { BlockStructure(1, level) })
+		assert.Panics(t, func() { DataCodewords(1, level) })
+		assert.Panics(t, func() { FormatInfoBits(level, 0) })
+	}
+	assert.Panics(t, func() { Mode(4).Indicator() })
+	assert.Panics(t, func() { CharCountBits(Mode(-1), 1) })
+}
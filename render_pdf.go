@@ -0,0 +1,450 @@
+package go_qr
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pointsPerMM converts millimetres to PDF points (1/72 inch).
+const pointsPerMM = 72 / 25.4
+
+// WithPDFPageSize sets the physical page size of PDF output, in millimetres.
+// The symbol and its quiet zone are scaled uniformly to fit the page and
+// centered on it. Without it the page measures scale points per module,
+// border included, the size in pixels of the PNG rendering.
+func WithPDFPageSize(widthMM, heightMM float64) Option {
+	return func(q *QrCodeImgConfig) {
+		q.pdfPageSize = [2]float64{widthMM, heightMM}
+	}
+}
+
+// WithPDFCMYK writes PDF colors, logo included, in the DeviceCMYK color space
+// instead of DeviceRGB. color.CMYK values are used as given; other colors are
+// converted with color.RGBToCMYK.
+func WithPDFCMYK() Option {
+	return func(q *QrCodeImgConfig) {
+		q.pdfCMYK = true
+	}
+}
+
+// PDF renders the QR code as a single-page vector PDF to the given file
+// path. Colors are taken from the config (WithLight / WithDark).
+func (q *QrCode) PDF(config *QrCodeImgConfig, filePath string) error {
+	if err := q.validatePDFConfig(config); err != nil {
+		return err
+	}
+
+	if ext := filepath.Ext(filePath); ext != ".pdf" {
+		return fmt.Errorf("%w: expected .pdf extension, got %q", ErrInvalidImageOutput, ext)
+	}
+
+	pdfFile, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating PDF file: %w", err)
+	}
+	defer pdfFile.Close()
+
+	return q.encodePDF(config, pdfFile)
+}
+
+// WriteAsPDF renders the QR code as PDF to the provided io.Writer.
+func (q *QrCode) WriteAsPDF(config *QrCodeImgConfig, writer io.Writer) error {
+	if err := q.validatePDFConfig(config); err != nil {
+		return err
+	}
+	return q.encodePDF(config, writer)
+}
+
+// ToPDFBytes renders the QR code as PDF and returns the bytes in memory.
+func (q *QrCode) ToPDFBytes(config *QrCodeImgConfig) ([]byte, error) {
+	if err := q.validatePDFConfig(config); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := q.encodePDF(config, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// validatePDFConfig validates the config for PDF output, page size included.
+func (q *QrCode) validatePDFConfig(config *QrCodeImgConfig) error {
+	if err := config.valid(); err != nil {
+		return err
+	}
+	w, h := config.pdfPageSize[0], config.pdfPageSize[1]
+	if w == 0 && h == 0 {
+		return nil
+	}
+	if !(w > 0 && h > 0) || math.IsInf(w, 0) || math.IsInf(h, 0) {
+		return fmt.Errorf("%w: PDF page size %gx%g mm must be positive", ErrInvalidConfig, w, h)
+	}
+	return nil
+}
+
+// encodePDF writes the PDF document: catalog, page tree, one page, its
+// content stream and the resources it uses. Module geometry is drawn as
+// filled paths in the pixel coordinates of the PNG rendering, flipped to the
+// PDF's bottom-up page space by the content stream's first operator.
+func (q *QrCode) encodePDF(config *QrCodeImgConfig, writer io.Writer) error {
+	// Objects 1-3 are the catalog, page tree and page, filled in last.
+	doc := &pdfDocument{objects: make([][]byte, 3)}
+	var resources []string
+
+	scale := config.scale
+	border := config.border * scale
+	w := float64(q.Width()*scale + 2*border)
+	h := float64(q.Height()*scale + 2*border)
+	pageW, pageH := w, h
+	if config.pdfPageSize != [2]float64{} {
+		pageW, pageH = config.pdfPageSize[0]*pointsPerMM, config.pdfPageSize[1]*pointsPerMM
+	}
+	s := min(pageW/w, pageH/h)
+
+	var c strings.Builder
+	fmt.Fprintf(&c, "%s 0 0 %s %s %s cm\n", formatFloat(s), formatFloat(-s), formatFloat((pageW-w*s)/2), formatFloat(pageH-(pageH-h*s)/2))
+
+	cmyk := config.pdfCMYK
+	if light := config.Light(); !colorIsTransparent(light) {
+		fmt.Fprintf(&c, "%s\n0 0 %s %s re\nf\n", pdfFillColor(light, cmyk), formatFloat(w), formatFloat(h))
+	}
+
+	if g := config.gradient; g != nil {
+		ref := doc.add([]byte(g.pdfShading(float64(border), float64(border), float64(q.Width()*scale), float64(q.Height()*scale), cmyk)))
+		resources = append(resources, fmt.Sprintf("/Shading << /Sh0 %d 0 R >>", ref))
+	}
+	// fill paints path with col, or with the dark fill when col is nil.
+	darkFill := pdfFillColor(config.Dark(), cmyk) + "\n"
+	fill := func(path string, col color.Color) {
+		switch {
+		case path == "":
+		case col != nil:
+			c.WriteString(pdfFillColor(col, cmyk) + "\n" + path + "f*\n")
+		case config.gradient != nil:
+			c.WriteString("q\n" + path + "W* n\n/Sh0 sh\nQ\n")
+		default:
+			c.WriteString(darkFill + path + "f*\n")
+		}
+	}
+
+	shaper := q.newModuleShaper(config)
+	eyes := q.styledEyes(config)
+	merged := q.mergedModules(shaper, eyes)
+	var path strings.Builder
+	p := &pdfPath{sb: &path}
+	q.assembleBorderGraphOf(merged).writeOutline(p, border, scale, config.cornerRadius)
+	if shaper != nil {
+		for y := 0; y < q.Height(); y++ {
+			for x := 0; x < q.Width(); x++ {
+				if q.Module(x, y) && eyeAt(eyes, x, y) == nil && !merged(x, y) {
+					shaper.writePath(p, x, y, float64(x*scale+border), float64(y*scale+border), float64(scale))
+				}
+			}
+		}
+	}
+	fill(path.String(), nil)
+
+	for _, e := range eyes {
+		ox, oy := float64(e.x*scale+border), float64(e.y*scale+border)
+		path.Reset()
+		e.frame.writePath(p, ox, oy, float64(scale))
+		e.frame.inset(1).writePath(p, ox, oy, float64(scale))
+		fill(path.String(), e.frameColor)
+		path.Reset()
+		e.pupil.writePath(p, ox, oy, float64(scale))
+		fill(path.String(), e.pupilColor)
+	}
+
+	if logo := config.logo; logo != nil {
+		if err := logo.validate(q); err != nil {
+			return err
+		}
+		ref, err := logo.pdfImage(doc, cmyk)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, fmt.Sprintf("/XObject << /Im0 %d 0 R >>", ref))
+		if err := logo.pdfDraw(&c, q.Size(), scale, config.border, cmyk); err != nil {
+			return err
+		}
+	}
+
+	content := doc.add(pdfStream("", deflate([]byte(c.String()))))
+	doc.objects[0] = []byte("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.objects[1] = []byte("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	doc.objects[2] = []byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents %d 0 R >>",
+		formatFloat(pageW), formatFloat(pageH), strings.Join(resources, " "), content))
+
+	if _, err := doc.WriteTo(writer); err != nil {
+		return fmt.Errorf("error writing PDF: %w", err)
+	}
+	return nil
+}
+
+// pdfDocument collects the indirect objects of a PDF file; object n+1 is
+// objects[n].
+type pdfDocument struct {
+	objects [][]byte
+}
+
+// add appends an object and returns its number.
+func (d *pdfDocument) add(obj []byte) int {
+	d.objects = append(d.objects, obj)
+	return len(d.objects)
+}
+
+// WriteTo writes the file: header, objects, cross-reference table and
+// trailer, with object 1 as the document catalog.
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(d.objects))
+	for i, obj := range d.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(obj)
+		buf.WriteString("\nendobj\n")
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, xref)
+	return buf.WriteTo(w)
+}
+
+// pdfStream returns a Flate-compressed stream object with the extra
+// dictionary entries dict.
+func pdfStream(dict string, data []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<< %s/Filter /FlateDecode /Length %d >>\nstream\n", dict, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	return buf.Bytes()
+}
+
+// deflate compresses data for a FlateDecode stream.
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// pdfColorComponents returns c as DeviceCMYK or DeviceRGB components in
+// [0, 1]. Alpha is ignored: the page is opaque.
+func pdfColorComponents(c color.Color, cmyk bool) []float64 {
+	if cmyk {
+		v, ok := c.(color.CMYK)
+		if !ok {
+			n := color.NRGBAModel.Convert(c).(color.NRGBA)
+			v.C, v.M, v.Y, v.K = color.RGBToCMYK(n.R, n.G, n.B)
+		}
+		return []float64{float64(v.C) / 255, float64(v.M) / 255, float64(v.Y) / 255, float64(v.K) / 255}
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return []float64{float64(n.R) / 255, float64(n.G) / 255, float64(n.B) / 255}
+}
+
+// pdfNumbers formats vs separated by spaces.
+func pdfNumbers(vs []float64) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = formatFloat(v)
+	}
+	return strings.Join(s, " ")
+}
+
+// pdfFillColor returns the operator setting the fill color to c.
+func pdfFillColor(c color.Color, cmyk bool) string {
+	if cmyk {
+		return pdfNumbers(pdfColorComponents(c, cmyk)) + " k"
+	}
+	return pdfNumbers(pdfColorComponents(c, cmyk)) + " rg"
+}
+
+// pdfColorSpace returns the device color space name.
+func pdfColorSpace(cmyk bool) string {
+	if cmyk {
+		return "/DeviceCMYK"
+	}
+	return "/DeviceRGB"
+}
+
+// pdfShading returns the shading dictionary of the gradient over the symbol
+// box at (x0, y0) of size w×h: an axial or radial shading whose function
+// stitches one linear interpolation per pair of adjacent stops, extended
+// past both ends like the clamped SVG and PNG gradients.
+func (g *gradient) pdfShading(x0, y0, w, h float64, cmyk bool) string {
+	ax, ay, bx, by := g.span(x0, y0, w, h)
+	typ, coords := 2, []float64{ax, ay, bx, by}
+	if g.radial {
+		typ, coords = 3, []float64{ax, ay, 0, ax, ay, bx - ax}
+	}
+
+	stops := g.stops
+	if first := stops[0]; first.Offset > 0 {
+		stops = append([]GradientStop{{0, first.Color}}, stops...)
+	}
+	if last := stops[len(stops)-1]; last.Offset < 1 {
+		stops = append(stops[:len(stops):len(stops)], GradientStop{1, last.Color})
+	}
+	var funcs []string
+	var bounds, encode []float64
+	for i := 1; i < len(stops); i++ {
+		funcs = append(funcs, fmt.Sprintf("<< /FunctionType 2 /Domain [0 1] /C0 [%s] /C1 [%s] /N 1 >>",
+			pdfNumbers(pdfColorComponents(stops[i-1].Color, cmyk)), pdfNumbers(pdfColorComponents(stops[i].Color, cmyk))))
+		if i > 1 {
+			bounds = append(bounds, stops[i-1].Offset)
+		}
+		encode = append(encode, 0, 1)
+	}
+	function := funcs[0]
+	if len(funcs) > 1 {
+		function = fmt.Sprintf("<< /FunctionType 3 /Domain [0 1] /Functions [%s] /Bounds [%s] /Encode [%s] >>",
+			strings.Join(funcs, " "), pdfNumbers(bounds), pdfNumbers(encode))
+	}
+	return fmt.Sprintf("<< /ShadingType %d /ColorSpace %s /Coords [%s] /Extend [true true] /Function %s >>",
+		typ, pdfColorSpace(cmyk), pdfNumbers(coords), function)
+}
+
+// pdfImage adds the logo image as an image XObject, with a soft mask when it
+// has transparent pixels, and returns its object number.
+func (l *logoConfig) pdfImage(doc *pdfDocument, cmyk bool) (int, error) {
+	if l.img == nil {
+		return 0, fmt.Errorf("%w: logo image is nil", ErrInvalidConfig)
+	}
+	b := l.img.Bounds()
+	var pix, alpha []byte
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := l.img.At(x, y)
+			for _, v := range pdfColorComponents(c, cmyk) {
+				pix = append(pix, byte(math.Round(v*255)))
+			}
+			a := color.NRGBAModel.Convert(c).(color.NRGBA).A
+			alpha = append(alpha, a)
+			opaque = opaque && a == 0xFF
+		}
+	}
+	dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 ",
+		b.Dx(), b.Dy(), pdfColorSpace(cmyk))
+	if !opaque {
+		mask := doc.add(pdfStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 ",
+			b.Dx(), b.Dy()), deflate(alpha)))
+		dict += fmt.Sprintf("/SMask %d 0 R ", mask)
+	}
+	return doc.add(pdfStream(dict, deflate(pix))), nil
+}
+
+// pdfDraw appends the operators painting the logo: its white padding, then
+// the image XObject /Im0 in the logo box, clipped to a disc for LogoCircle.
+// The geometry is that of overlayOnImage.
+func (l *logoConfig) pdfDraw(c *strings.Builder, qrSize, scale, border int, cmyk bool) error {
+	rect, _, err := l.logoRect(qrSize, scale, border)
+	if err != nil {
+		return err
+	}
+	logoRect := rect.Inset(scale)
+
+	white := pdfFillColor(color.White, cmyk)
+	var clip string
+	if l.shape == LogoCircle {
+		boxModules, _ := l.boxModules(qrSize)
+		center := float64(border*scale) + float64(qrSize*scale)/2
+		r := float64(boxModules*scale) / 2
+		c.WriteString(white + "\n" + pdfCircle(center, center, r) + "f\n")
+		clip = pdfCircle(center, center, r-float64(scale)) + "W n\n"
+	} else {
+		fmt.Fprintf(c, "%s\n%s re\nf\n", white, pdfRect(rect))
+	}
+	fmt.Fprintf(c, "q\n%s%d 0 0 %d %d %d cm\n/Im0 Do\nQ\n", clip, logoRect.Dx(), -logoRect.Dy(), logoRect.Min.X, logoRect.Max.Y)
+	return nil
+}
+
+// pdfRect returns the operands of the re operator for r.
+func pdfRect(r image.Rectangle) string {
+	return fmt.Sprintf("%d %d %d %d", r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+}
+
+// pdfCircle returns the path of a circle.
+func pdfCircle(cx, cy, r float64) string {
+	var sb strings.Builder
+	p := &pdfPath{sb: &sb}
+	p.moveTo(cx-r, cy)
+	p.arcTo(r, cx+r, cy, true)
+	p.arcTo(r, cx-r, cy, true)
+	p.closePath()
+	return sb.String()
+}
+
+// pdfPath writes path commands as PDF path construction operators, arcs as
+// cubic Bézier curves of at most a quarter turn each.
+type pdfPath struct {
+	sb   *strings.Builder
+	x, y float64 // current point
+}
+
+func (p *pdfPath) moveTo(x, y float64) {
+	p.sb.WriteString(formatFloat(x) + " " + formatFloat(y) + " m\n")
+	p.x, p.y = x, y
+}
+
+func (p *pdfPath) lineTo(x, y float64) {
+	p.sb.WriteString(formatFloat(x) + " " + formatFloat(y) + " l\n")
+	p.x, p.y = x, y
+}
+
+func (p *pdfPath) arcTo(r, x, y float64, clockwise bool) {
+	dx, dy := x-p.x, y-p.y
+	d := math.Hypot(dx, dy)
+	if d == 0 || r == 0 {
+		p.lineTo(x, y)
+		return
+	}
+	// The center lies on the chord's perpendicular bisector, on the side
+	// that keeps the arc within a half turn. Angles grow clockwise on screen.
+	off := math.Sqrt(max(r*r-d*d/4, 0)) / d
+	if !clockwise {
+		off = -off
+	}
+	cx, cy := (p.x+x)/2-dy*off, (p.y+y)/2+dx*off
+	start := math.Atan2(p.y-cy, p.x-cx)
+	sweep := math.Atan2(y-cy, x-cx) - start
+	for clockwise && sweep <= 0 {
+		sweep += 2 * math.Pi
+	}
+	for !clockwise && sweep >= 0 {
+		sweep -= 2 * math.Pi
+	}
+
+	n := int(math.Ceil(math.Abs(sweep)/(math.Pi/2) - 1e-9))
+	step := sweep / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4) * r
+	for i := 0; i < n; i++ {
+		a0, a1 := start+step*float64(i), start+step*float64(i+1)
+		x0, y0 := cx+r*math.Cos(a0), cy+r*math.Sin(a0)
+		x3, y3 := cx+r*math.Cos(a1), cy+r*math.Sin(a1)
+		if i == n-1 {
+			x3, y3 = x, y
+		}
+		fmt.Fprintf(p.sb, "%s %s %s %s %s %s c\n",
+			formatFloat(x0-k*math.Sin(a0)), formatFloat(y0+k*math.Cos(a0)),
+			formatFloat(x3+k*math.Sin(a1)), formatFloat(y3-k*math.Cos(a1)),
+			formatFloat(x3), formatFloat(y3))
+	}
+	p.x, p.y = x, y
+}
+
+func (p *pdfPath) closePath() { p.sb.WriteString("h\n") }
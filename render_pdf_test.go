@@ -0,0 +1,195 @@
+package go_qr
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pdfObjects parses a PDF written by encodePDF, checking the cross-reference
+// table, and returns its objects by number.
+func pdfObjects(t *testing.T, pdf []byte) map[int]string {
+	t.Helper()
+	s := string(pdf)
+	assert.True(t, strings.HasPrefix(s, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(s, "%%EOF\n"))
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(s)
+	if !assert.NotNil(t, m) {
+		return nil
+	}
+	xref, _ := strconv.Atoi(m[1])
+	assert.True(t, strings.HasPrefix(s[xref:], "xref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(s[xref:], -1)
+
+	objs := map[int]string{}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		header := strconv.Itoa(i+1) + " 0 obj\n"
+		assert.True(t, strings.HasPrefix(s[off:], header), "object %d", i+1)
+		body := s[off+len(header):]
+		objs[i+1] = body[:strings.Index(body, "\nendobj\n")]
+	}
+	return objs
+}
+
+// pdfStreamData inflates the data of a stream object.
+func pdfStreamData(t *testing.T, obj string) string {
+	t.Helper()
+	start := strings.Index(obj, "stream\n") + len("stream\n")
+	end := strings.LastIndex(obj, "\nendstream")
+	zr, err := zlib.NewReader(strings.NewReader(obj[start:end]))
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	data, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+// pdfContent returns the page and the inflated content stream.
+func pdfContent(t *testing.T, pdf []byte) (page, content string) {
+	t.Helper()
+	objs := pdfObjects(t, pdf)
+	page = objs[3]
+	m := regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(page)
+	if !assert.NotNil(t, m) {
+		return page, ""
+	}
+	n, _ := strconv.Atoi(m[1])
+	return page, pdfStreamData(t, objs[n])
+}
+
+func TestQrCode_PDF(t *testing.T) {
+	qr, err := EncodeText("PDF", Low)
+	assert.NoError(t, err)
+
+	pdf, err := qr.ToPDFBytes(NewQrCodeImgConfig(10, 4))
+	assert.NoError(t, err)
+	page, content := pdfContent(t, pdf)
+	// 21 modules and 4 border modules a side, 10 points each.
+	assert.Contains(t, page, "/MediaBox [0 0 290 290]")
+	assert.True(t, strings.HasPrefix(content, "1 0 0 -1 0 290 cm\n1 1 1 rg\n0 0 290 290 re\nf\n0 0 0 rg\n"))
+	assert.True(t, strings.HasSuffix(content, "f*\n"))
+	// The outer edge of the top-left finder.
+	assert.Contains(t, content, "40 40 m\n110 40 l\n110 110 l\n40 110 l\n40 40 l\nh\n")
+
+	var buf bytes.Buffer
+	assert.NoError(t, qr.WriteAsPDF(NewQrCodeImgConfig(10, 4), &buf))
+	assert.Equal(t, pdf, buf.Bytes())
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "qr.pdf")
+	assert.NoError(t, qr.PDF(NewQrCodeImgConfig(10, 4), dest))
+	written, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, pdf, written)
+	assert.ErrorIs(t, qr.PDF(NewQrCodeImgConfig(10, 4), filepath.Join(dir, "qr.png")), ErrInvalidImageOutput)
+}
+
+func TestPDFPageSizeAndColors(t *testing.T) {
+	qr, err := EncodeText("PAGE", Low)
+	assert.NoError(t, err)
+
+	// A 50×100 mm page fits the 290-unit symbol to its width and centers
+	// it vertically.
+	pdf, err := qr.ToPDFBytes(NewQrCodeImgConfig(10, 4, WithPDFPageSize(50, 100)))
+	assert.NoError(t, err)
+	page, content := pdfContent(t, pdf)
+	assert.Contains(t, page, "/MediaBox [0 0 141.732 283.465]")
+	assert.True(t, strings.HasPrefix(content, "0.489 0 0 -0.489 0 212.598 cm\n"))
+
+	// CMYK, with CMYK input kept exactly.
+	pdf, err = qr.ToPDFBytes(NewQrCodeImgConfig(10, 4, WithPDFCMYK(),
+		WithDark(color.CMYK{C: 255, M: 128, Y: 0, K: 51}), WithLight(color.Transparent)))
+	assert.NoError(t, err)
+	_, content = pdfContent(t, pdf)
+	assert.Contains(t, content, "1 0.502 0 0.2 k\n")
+	assert.NotContains(t, content, " re\n")
+	assert.NotContains(t, content, " rg\n")
+
+	for _, size := range [][2]float64{{-1, 10}, {10, 0}, {math.NaN(), 5}, {math.Inf(1), 5}} {
+		_, err := qr.ToPDFBytes(NewQrCodeImgConfig(10, 4, WithPDFPageSize(size[0], size[1])))
+		assert.ErrorIs(t, err, ErrInvalidConfig, "%v", size)
+	}
+	_, err = qr.ToPDFBytes(NewQrCodeImgConfig(0, 4))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestPDFStyles(t *testing.T) {
+	qr, err := EncodeText("STYLED PDF", Low)
+	assert.NoError(t, err)
+	red := color.RGBA{R: 0xCC, A: 0xFF}
+	pdf, err := qr.ToPDFBytes(NewQrCodeImgConfig(10, 4,
+		WithModuleShape(ModuleCircle),
+		WithCornerRadius(0.5),
+		WithEyeStyle(EyeStyle{FrameShape: EyeRounded, PupilShape: EyeCircle, PupilColor: red}),
+		WithLinearGradient(90, GradientStop{0, navy}, GradientStop{0.5, color.Black}, GradientStop{1, maroon})))
+	assert.NoError(t, err)
+	objs := pdfObjects(t, pdf)
+	page, content := pdfContent(t, pdf)
+	assert.Contains(t, page, "/Shading << /Sh0 4 0 R >>")
+	assert.Contains(t, objs[4], "/ShadingType 2 /ColorSpace /DeviceRGB /Coords [145 40 145 250]")
+	assert.Contains(t, objs[4], "/FunctionType 3 /Domain [0 1]")
+	assert.Contains(t, objs[4], "/Bounds [0.5] /Encode [0 1 0 1]")
+	// Modules and eye frames are clipped to the gradient, pupils painted red.
+	assert.Equal(t, 4, strings.Count(content, "W* n\n/Sh0 sh\n"))
+	assert.Equal(t, 3, strings.Count(content, "0.8 0 0 rg\n"))
+	assert.Contains(t, content, " c\n")
+}
+
+func TestPDFLogo(t *testing.T) {
+	qr, err := EncodeText("https://example.com/pdf-logo", High)
+	assert.NoError(t, err)
+	for _, shape := range []LogoShape{LogoSquare, LogoCircle} {
+		logo := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+		logo.Set(0, 0, color.NRGBA{R: 255, A: 128})
+		pdf, err := qr.ToPDFBytes(NewQrCodeImgConfig(10, 4, WithShapedLogo(logo, 0.2, shape), WithPDFCMYK()))
+		assert.NoError(t, err)
+		objs := pdfObjects(t, pdf)
+		page, content := pdfContent(t, pdf)
+		assert.Contains(t, page, "/XObject << /Im0 5 0 R >>")
+		assert.Contains(t, objs[5], "/Subtype /Image /Width 4 /Height 2 /ColorSpace /DeviceCMYK /BitsPerComponent 8 /SMask 4 0 R")
+		assert.Contains(t, objs[4], "/ColorSpace /DeviceGray")
+		assert.Equal(t, 8*4, len(pdfStreamData(t, objs[5])))
+		assert.Equal(t, []byte{128, 0, 0, 0, 0, 0, 0, 0}, []byte(pdfStreamData(t, objs[4])))
+		assert.Contains(t, content, "/Im0 Do\nQ\n")
+		if shape == LogoCircle {
+			assert.Contains(t, content, "W n\n")
+		}
+	}
+
+	// A logo too large for the ECC budget fails like the other renderers.
+	low, err := EncodeText("https://example.com/pdf-logo", Low)
+	assert.NoError(t, err)
+	_, err = low.ToPDFBytes(NewQrCodeImgConfig(10, 4, WithLogo(makeTestLogo(8, 8, color.Black), 0.4)))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestPDFPathArc(t *testing.T) {
+	var sb strings.Builder
+	p := &pdfPath{sb: &sb}
+	// A clockwise quarter turn on screen from the top to the right of the
+	// unit circle.
+	p.moveTo(0, -1)
+	p.arcTo(1, 1, 0, true)
+	assert.Equal(t, "0 -1 m\n0.552 -1 1 -0.552 1 0 c\n", sb.String())
+
+	// A half turn takes two quarter curves; counterclockwise bulges the
+	// other way.
+	sb.Reset()
+	p.moveTo(-1, 0)
+	p.arcTo(1, 1, 0, false)
+	assert.Equal(t, "-1 0 m\n-1 0.552 -0.552 1 0 1 c\n0.552 1 1 0.552 1 0 c\n", sb.String())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"image"
+	"image/color"
 	"image/png"
 	"io"
 	"math"
@@ -64,7 +65,7 @@ func (q *QrCode) ToImage(config *QrCodeImgConfig) (*image.RGBA, error) {
 func (q *QrCode) renderImage(config *QrCodeImgConfig) (*image.RGBA, error) {
 	rgba := q.paintModules(config)
 	if logo := config.logo; logo != nil {
-		if err := logo.validate(q, config.scale, config.border); err != nil {
+		if err := logo.validate(q); err != nil {
 			return nil, err
 		}
 		if err := logo.overlayOnImage(rgba, q.Size(), config.scale, config.border); err != nil {
@@ -93,25 +94,61 @@ func (q *QrCode) validateWritePNGConfig(config *QrCodeImgConfig) error {
 	}
 	// Ensure that the border size combined with QR code size does not exceed
 	// the maximum allowed integer value after scaling.
-	if config.border > (math.MaxInt32/2) || int64(q.Size())+int64(config.border)*2 > math.MaxInt32/int64(config.scale) {
+	if config.border > (math.MaxInt32/2) || int64(max(q.Width(), q.Height()))+int64(config.border)*2 > math.MaxInt32/int64(config.scale) {
 		return fmt.Errorf("%w: scale or border too large", ErrInvalidConfig)
 	}
 	return nil
 }
 
 // paintModules allocates an RGBA image and fills each pixel with the dark or
-// light color according to the QR module at that position.
+// light color according to the QR module at that position. With a module
+// shape configured, a pixel of a dark module is dark only if its center lies
+// inside the shape. Styled finder eyes are painted in their own colors, and a
+// gradient gives each dark pixel the color at its center. With a corner
+// radius, merged square modules follow the rounded outline.
 func (q *QrCode) paintModules(config *QrCodeImgConfig) *image.RGBA {
-	size := q.Size() + config.border*2
-	imageWidth := size * config.scale
-	imageHeight := size * config.scale
+	imageWidth := (q.Width() + config.border*2) * config.scale
+	imageHeight := (q.Height() + config.border*2) * config.scale
 	result := image.NewRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+	shaper := q.newModuleShaper(config)
+	eyes := q.styledEyes(config)
+	merged := q.mergedModules(shaper, eyes)
+	scale := float64(config.scale)
+	darkAt := func(x, y int) color.Color { return config.Dark() }
+	if g := config.gradient; g != nil {
+		brd := float64(config.border * config.scale)
+		ax, ay, bx, by := g.span(brd, brd, float64(q.Width()*config.scale), float64(q.Height()*config.scale))
+		darkAt = func(x, y int) color.Color {
+			return g.colorAt(g.offsetAt(float64(x)+0.5, float64(y)+0.5, ax, ay, bx, by))
+		}
+	}
 	for y := 0; y < imageHeight; y++ {
 		for x := 0; x < imageWidth; x++ {
 			moduleX := x/config.scale - config.border
 			moduleY := y/config.scale - config.border
-			if q.Module(moduleX, moduleY) {
-				result.Set(x, y, config.Dark())
+			fx := (float64(x%config.scale) + 0.5) / scale
+			fy := (float64(y%config.scale) + 0.5) / scale
+			if e := eyeAt(eyes, moduleX, moduleY); e != nil {
+				c, painted := e.colorAt(float64(moduleX-e.x)+fx, float64(moduleY-e.y)+fy)
+				switch {
+				case !painted:
+					result.Set(x, y, config.Light())
+				case c == nil:
+					result.Set(x, y, darkAt(x, y))
+				default:
+					result.Set(x, y, c)
+				}
+				continue
+			}
+			dark := q.Module(moduleX, moduleY)
+			if dark && shaper != nil {
+				dark = shaper.contains(moduleX, moduleY, fx, fy)
+			}
+			if config.cornerRadius > 0 && (!dark || merged(moduleX, moduleY)) {
+				dark = roundedContains(merged, config.cornerRadius, moduleX, moduleY, fx, fy)
+			}
+			if dark {
+				result.Set(x, y, darkAt(x, y))
 			} else {
 				result.Set(x, y, config.Light())
 			}
@@ -60,16 +60,19 @@ func (q *QrCode) doWriteAsSVG(config *QrCodeImgConfig, writer io.Writer) error {
 		light = colorToSVGHex(config.Light())
 	}
 	dark := colorToSVGHex(config.Dark())
+	if config.gradient != nil {
+		dark = "url(#" + svgGradientID + ")"
+	}
 
 	svg := ""
-	if config.optimalSVG {
+	if config.optimalSVG || config.cornerRadius > 0 {
 		svg = q.toSvgOptimizedString(config, light, dark)
 	} else {
 		svg = q.toSVGString(config, light, dark)
 	}
 
 	if logo := config.logo; logo != nil {
-		if err := logo.validate(q, config.scale, config.border); err != nil {
+		if err := logo.validate(q); err != nil {
 			return err
 		}
 		fragment, err := logo.svgEmbed(q.Size(), config.scale, config.border)
@@ -102,43 +105,57 @@ func injectSVGFragment(svg, fragment string) string {
 func (q *QrCode) toSVGString(config *QrCodeImgConfig, lightColor, darkColor string) string {
 	brd := config.border
 	scl := config.scale
-	size := q.Size()
-	dim := size*scl + brd*2
-	dimStr := strconv.Itoa(dim)
+	width, height := q.Width(), q.Height()
+	widthStr := strconv.Itoa(width*scl + brd*2)
+	heightStr := strconv.Itoa(height*scl + brd*2)
 	sclStr := strconv.Itoa(scl)
 
 	sb := strings.Builder{}
 	// Header + rect + path wrapper ≈ 200 bytes; each dark module emits
-	// roughly 20 bytes of path data. size*size is the upper bound on
+	// roughly 20 bytes of path data. width*height is the upper bound on
 	// dark modules, so this over-allocates but avoids repeated regrowth.
-	sb.Grow(256 + size*size*20)
+	sb.Grow(256 + width*height*20)
 
 	if config.svgXMLHeader {
 		sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
 		sb.WriteString("<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n")
 	}
 	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 `)
-	sb.WriteString(dimStr)
+	sb.WriteString(widthStr)
 	sb.WriteByte(' ')
-	sb.WriteString(dimStr)
+	sb.WriteString(heightStr)
 	sb.WriteString("\" stroke=\"none\">\n")
 
 	sb.WriteString("\t<rect width=\"")
-	sb.WriteString(dimStr)
+	sb.WriteString(widthStr)
 	sb.WriteString("\" height=\"")
-	sb.WriteString(dimStr)
+	sb.WriteString(heightStr)
 	sb.WriteString("\" fill=\"")
 	sb.WriteString(lightColor)
 	sb.WriteString("\"/>\n")
 
+	if g := config.gradient; g != nil {
+		g.writeSVGDefs(&sb, float64(brd), float64(brd), float64(width*scl), float64(height*scl))
+	}
+
 	sb.WriteString("\t<path d=\"")
 	// Scratch buffer for strconv.AppendInt — avoids the per-call allocation
 	// that strconv.Itoa makes for each coordinate.
 	var scratch [20]byte
 	first := true
-	for y := 0; y < size; y++ {
-		for x := 0; x < size; x++ {
-			if !q.Module(x, y) {
+	shaper := q.newModuleShaper(config)
+	eyes := q.styledEyes(config)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !q.Module(x, y) || eyeAt(eyes, x, y) != nil {
+				continue
+			}
+			if shaper != nil {
+				if !first {
+					sb.WriteByte(' ')
+				}
+				first = false
+				shaper.writePath(svgPath{&sb}, x, y, float64(x*scl+brd), float64(y*scl+brd), float64(scl))
 				continue
 			}
 			if !first {
@@ -160,7 +177,11 @@ func (q *QrCode) toSVGString(config *QrCodeImgConfig, lightColor, darkColor stri
 	}
 	sb.WriteString("\" fill=\"")
 	sb.WriteString(darkColor)
-	sb.WriteString("\"/>\n</svg>\n")
+	sb.WriteString("\"/>\n")
+	for i := range eyes {
+		eyes[i].writeSVG(&sb, float64(eyes[i].x*scl+brd), float64(eyes[i].y*scl+brd), float64(scl), darkColor)
+	}
+	sb.WriteString("</svg>\n")
 
 	return sb.String()
 }
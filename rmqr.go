@@ -0,0 +1,342 @@
+package go_qr
+
+import "fmt"
+
+// MinRMQRVersion / MaxRMQRVersion define the rMQR version range. Versions are
+// numbered 1-32 in the order of rmqrDimensions (R7x43 ... R17x139); use
+// RMQRVersion to look one up by size.
+const (
+	MinRMQRVersion = 1
+	MaxRMQRVersion = 32
+)
+
+// rmqrMask is the only data mask rMQR defines. It is QR Code mask 4.
+const rmqrMask = 4
+
+// rmqrCharCountBits is the width of the character count indicator, indexed by
+// mode (numeric, alphanumeric, byte, kanji) and rMQR version.
+var rmqrCharCountBits = [4][33]int{
+	{-1, 4, 5, 6, 7, 7, 5, 6, 7, 7, 8, 4, 6, 7, 7, 8, 8, 5, 6, 7, 7, 8, 8, 7, 7, 8, 8, 9, 7, 8, 8, 8, 9},
+	{-1, 3, 5, 5, 6, 6, 5, 5, 6, 6, 7, 4, 5, 6, 6, 7, 7, 5, 6, 6, 7, 7, 8, 6, 7, 7, 7, 8, 6, 7, 7, 8, 8},
+	{-1, 3, 4, 5, 5, 6, 4, 5, 5, 6, 6, 3, 5, 5, 6, 6, 7, 4, 5, 6, 6, 7, 7, 6, 6, 7, 7, 7, 6, 6, 7, 7, 8},
+	{-1, 2, 3, 4, 5, 5, 3, 4, 5, 5, 6, 2, 4, 5, 5, 6, 6, 3, 5, 5, 6, 6, 7, 5, 5, 6, 6, 7, 5, 6, 6, 6, 7},
+}
+
+// RMQRVersion returns the rMQR version number of the symbol with the given
+// height and width in modules, e.g. RMQRVersion(7, 139) for R7x139.
+func RMQRVersion(height, width int) (int, error) {
+	for ver := MinRMQRVersion; ver <= MaxRMQRVersion; ver++ {
+		if rmqrDimensions[ver] == [2]int{height, width} {
+			return ver, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: no rMQR symbol is %d×%d modules", ErrInvalidVersion, height, width)
+}
+
+// rmqrHeader returns the 3-bit rMQR mode indicator and character-count width
+// of m in version ver. ok is false for modes rMQR does not define, such as
+// Structured Append.
+func (m Mode) rmqrHeader(ver int) (indicator, ccBits int, ok bool) {
+	switch {
+	case m.isNumeric():
+		return 1, rmqrCharCountBits[0][ver], true
+	case m.isAlphanumeric():
+		return 2, rmqrCharCountBits[1][ver], true
+	case m.isByte():
+		return 3, rmqrCharCountBits[2][ver], true
+	case m.isKanji():
+		return 4, rmqrCharCountBits[3][ver], true
+	case m == fnc1First:
+		return 5, 0, true
+	case m == fnc1Second:
+		return 6, 0, true
+	case m.isEci():
+		return 7, 0, true
+	}
+	return 0, 0, false
+}
+
+// rmqrEccRow maps Medium and High onto the rows of the rMQR ECC tables.
+func rmqrEccRow(ecl Ecc) int {
+	if ecl == High {
+		return 1
+	}
+	return 0
+}
+
+// getRMQRNumDataCodewords is the rMQR counterpart of getNumDataCodewords.
+func getRMQRNumDataCodewords(ver int, ecl Ecc) int {
+	row := rmqrEccRow(ecl)
+	return getRMQRNumRawDataModules(ver)/8 -
+		int(rmqrEccCodeWordsPerBlock[row][ver])*int(rmqrNumErrorCorrectionBlocks[row][ver])
+}
+
+// getRMQRTotalBits returns the number of bits needed to encode segs in rMQR
+// version ver, or -1 if a segment's mode is unavailable in rMQR or its
+// character count overflows the count field.
+func getRMQRTotalBits(segs []*QrSegment, ver int) int {
+	res := 0
+	for _, seg := range segs {
+		if seg == nil {
+			continue
+		}
+		_, ccBits, ok := seg.mode.rmqrHeader(ver)
+		if !ok || seg.numChars >= 1<<uint(ccBits) {
+			return -1
+		}
+		res += 3 + ccBits + seg.data.len()
+	}
+	return res
+}
+
+// EncodeRMQRText encodes text as the rMQR symbol with the smallest area that
+// holds it at the given error correction level (Medium or High), boosting to
+// High when the data still fits.
+func EncodeRMQRText(text string, ecl Ecc) (*QrCode, error) {
+	segs, err := MakeSegments(text)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeRMQRSegments(segs, ecl, MinRMQRVersion, MaxRMQRVersion, true)
+}
+
+// EncodeRMQRSegments is the rMQR counterpart of EncodeSegments. Among the
+// versions in [minVer, maxVer] it picks the one with the smallest area that
+// fits; since versions are ordered by height, a range such as
+// RMQRVersion(7, 43) .. RMQRVersion(7, 139) limits the symbol to 7 rows. rMQR
+// only offers Medium and High, and always uses a single fixed mask.
+func EncodeRMQRSegments(segs []*QrSegment, ecl Ecc, minVer, maxVer int, boostEcl bool) (*QrCode, error) {
+	if segs == nil {
+		return nil, fmt.Errorf("%w: segments slice is nil", ErrInvalidArgument)
+	}
+	if !(MinRMQRVersion <= minVer && minVer <= maxVer && maxVer <= MaxRMQRVersion) {
+		return nil, fmt.Errorf("%w: minVer=%d maxVer=%d", ErrInvalidVersion, minVer, maxVer)
+	}
+	if ecl != Medium && ecl != High {
+		return nil, fmt.Errorf("%w: rMQR only offers Medium and High error correction", ErrInvalidArgument)
+	}
+
+	version, dataUsedBits, bestArea := 0, -1, 0
+	tooLong := &DataTooLongError{RequiredBits: -1, Ecc: ecl}
+	for ver := minVer; ver <= maxVer; ver++ {
+		usedBits := getRMQRTotalBits(segs, ver)
+		if capacity := getRMQRNumDataCodewords(ver, ecl) * 8; usedBits == -1 || usedBits > capacity {
+			if capacity > tooLong.AvailableBits {
+				tooLong.RequiredBits, tooLong.AvailableBits, tooLong.Version = usedBits, capacity, ver
+			}
+			continue
+		}
+		if area := rmqrDimensions[ver][0] * rmqrDimensions[ver][1]; version == 0 || area < bestArea {
+			version, dataUsedBits, bestArea = ver, usedBits, area
+		}
+	}
+	if version == 0 {
+		return nil, tooLong
+	}
+
+	if boostEcl && ecl == Medium && dataUsedBits <= getRMQRNumDataCodewords(version, High)*8 {
+		ecl = High
+	}
+
+	bb := BitBuffer{}
+	for _, seg := range segs {
+		if seg == nil {
+			continue
+		}
+		indicator, ccBits, _ := seg.mode.rmqrHeader(version)
+		if err := bb.appendBits(indicator, 3); err != nil {
+			return nil, err
+		}
+		if err := bb.appendBits(seg.numChars, ccBits); err != nil {
+			return nil, err
+		}
+		if err := bb.appendData(seg.data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Terminator, bit padding and alternating pad codewords.
+	capacity := getRMQRNumDataCodewords(version, ecl) * 8
+	if err := bb.appendBits(0, min(3, capacity-bb.len())); err != nil {
+		return nil, err
+	}
+	if err := bb.appendBits(0, (8-bb.len()%8)%8); err != nil {
+		return nil, err
+	}
+	for padByte := 0xEC; bb.len() < capacity; padByte ^= 0xEC ^ 0x11 {
+		if err := bb.appendBits(padByte, 8); err != nil {
+			return nil, err
+		}
+	}
+
+	dataCodewords := make([]byte, bb.len()/8)
+	for i := 0; i < bb.len(); i++ {
+		if bb.getBit(i) {
+			dataCodewords[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+	return newRMQRCode(version, ecl, dataCodewords)
+}
+
+// newRMQRCode lays out an rMQR symbol from its data codewords.
+func newRMQRCode(ver int, ecl Ecc, dataCodewords []byte) (*QrCode, error) {
+	b := newRMQRBuilder(ver, ecl)
+	b.drawRMQRFunctionPatterns()
+
+	allCodewords, err := b.addRMQREccAndInterleave(dataCodewords)
+	if err != nil {
+		return nil, err
+	}
+	b.drawRMQRCodewords(allCodewords)
+	if err := b.applyMask(rmqrMask); err != nil {
+		return nil, err
+	}
+	return b.toQrCode(rmqrMask), nil
+}
+
+// newRMQRBuilder allocates a blank builder for rMQR version ver.
+func newRMQRBuilder(ver int, ecl Ecc) *builder {
+	b := newSizedBuilder(ver, rmqrDimensions[ver][1], rmqrDimensions[ver][0], ecl)
+	b.symbol = SymbolRMQR
+	return b
+}
+
+// rmqrAlignmentPositions returns the x coordinates of the alignment pattern
+// columns for a symbol of the given width. R*x27 has none.
+func rmqrAlignmentPositions(width int) []int {
+	switch width {
+	case 43:
+		return []int{21}
+	case 59:
+		return []int{19, 39}
+	case 77:
+		return []int{25, 51}
+	case 99:
+		return []int{23, 49, 75}
+	case 139:
+		return []int{27, 55, 83, 111}
+	}
+	return []int{}
+}
+
+// drawRMQRFunctionPatterns draws the rMQR function patterns: timing patterns
+// along all four edges, the finder pattern at the top left, the finder
+// sub-pattern at the bottom right, the corner patterns, the alignment columns
+// and the two format information areas.
+func (q *builder) drawRMQRFunctionPatterns() {
+	width, height := q.size, q.height
+
+	// Edge timing patterns; the patterns drawn below overwrite their share.
+	q.kind = ModuleTiming
+	for x := 0; x < width; x++ {
+		q.setFunctionModule(x, 0, x%2 == 0)
+		q.setFunctionModule(x, height-1, x%2 == 0)
+	}
+	for y := 1; y < height-1; y++ {
+		q.setFunctionModule(0, y, y%2 == 0)
+		q.setFunctionModule(width-1, y, y%2 == 0)
+	}
+
+	q.drawFinderPattern(3, 3)
+
+	// Finder sub-pattern: a 5×5 ring around a single dark module.
+	q.kind = ModuleFinderSubPattern
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunctionModule(width-3+dx, height-3+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+
+	// Corner patterns at the top right and, from R11 up, the bottom left.
+	q.kind = ModuleCorner
+	for i := 1; i <= 3; i++ {
+		q.setFunctionModule(width-i, 0, true)
+		q.setFunctionModule(i-1, height-1, true)
+	}
+	q.setFunctionModule(width-1, 1, true)
+	q.setFunctionModule(width-2, 1, false)
+	if height >= 11 {
+		q.setFunctionModule(0, height-2, true)
+		q.setFunctionModule(1, height-2, false)
+	}
+
+	// Alignment patterns at the top and bottom edges, joined by a vertical
+	// timing pattern.
+	for _, cx := range rmqrAlignmentPositions(width) {
+		q.kind = ModuleAlignment
+		for dy := 0; dy < 3; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				ring := dx != 0 || dy != 1
+				q.setFunctionModule(cx+dx, dy, ring)
+				q.setFunctionModule(cx+dx, height-3+dy, ring)
+			}
+		}
+		q.kind = ModuleTiming
+		for y := 3; y < height-3; y++ {
+			q.setFunctionModule(cx, y, y%2 == 0)
+		}
+	}
+
+	q.drawRMQRFormatBits()
+}
+
+// drawRMQRFormatBits encodes the ECC level and version into the 18-bit format
+// information and draws the two differently masked copies: to the right of the
+// finder pattern and to the left of the finder sub-pattern.
+func (q *builder) drawRMQRFormatBits() {
+	data := rmqrEccRow(q.errorCorrectionLevel)<<5 | (q.version - 1)
+	rem := data
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := data<<12 | rem
+	left, right := bits^0x1FAB2, bits^0x20A7B
+	q.kind = ModuleFormat
+
+	width, height := q.size, q.height
+	for i := 0; i < 15; i++ {
+		q.setFunctionModule(8+i/5, 1+i%5, getBit(left, i))
+		q.setFunctionModule(width-8+i/5, height-6+i%5, getBit(right, i))
+	}
+	for i := 15; i < 18; i++ {
+		q.setFunctionModule(11, 1+i-15, getBit(left, i))
+		q.setFunctionModule(width-5+i-15, height-6, getBit(right, i))
+	}
+}
+
+// addRMQREccAndInterleave is the rMQR counterpart of addEccAndInterLeave.
+func (q *builder) addRMQREccAndInterleave(data []byte) ([]byte, error) {
+	numDataCodewords := getRMQRNumDataCodewords(q.version, q.errorCorrectionLevel)
+	if len(data) != numDataCodewords {
+		return nil, fmt.Errorf("%w: data length %d != expected %d", ErrInvalidArgument, len(data), numDataCodewords)
+	}
+
+	row := rmqrEccRow(q.errorCorrectionLevel)
+	numBlocks := rmqrNumErrorCorrectionBlocks[row][q.version]
+	blockEccLen := rmqrEccCodeWordsPerBlock[row][q.version]
+	rawCodewords := getRMQRNumRawDataModules(q.version) / 8
+	return interleaveBlocks(data, rawCodewords, int(numBlocks), int(blockEccLen))
+}
+
+// drawRMQRCodewords fills the non-function modules with the codeword bytes.
+// The zig-zag runs in two-module columns from the right edge, starting upward;
+// no column is skipped and remainder modules stay light.
+func (q *builder) drawRMQRCodewords(data []byte) {
+	i := 0
+	for right := q.size - 2; right >= 1; right -= 2 {
+		upward := (q.size-2-right)/2%2 == 0
+		for vert := 0; vert < q.height; vert++ {
+			y := vert
+			if upward {
+				y = q.height - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !q.isFunction[y][x] && i < len(data)*8 {
+					q.setCodewordModule(x, y, data, i)
+					i++
+				}
+			}
+		}
+	}
+}
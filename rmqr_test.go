@@ -0,0 +1,130 @@
+package go_qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRMQRCapacityTables checks the layout-derived codeword counts against the
+// data capacities published in ISO/IEC 23941 and that every block count
+// divides the ECC codewords into whole blocks.
+func TestRMQRCapacityTables(t *testing.T) {
+	wantData := [2][33]int{
+		{0, 6, 12, 20, 28, 44, 12, 21, 31, 42, 63, 7, 19, 31, 43, 57, 84, 12, 27, 38, 53, 73, 106, 33, 48, 67, 88, 127, 39, 56, 78, 100, 152},
+		{0, 3, 7, 10, 14, 24, 7, 11, 17, 22, 33, 5, 11, 15, 23, 29, 42, 7, 13, 20, 29, 35, 54, 15, 26, 31, 48, 69, 21, 28, 38, 56, 76},
+	}
+	for ver := MinRMQRVersion; ver <= MaxRMQRVersion; ver++ {
+		b := newRMQRBuilder(ver, Medium)
+		b.drawRMQRFunctionPatterns()
+		free := 0
+		for y := range b.isFunction {
+			for _, fn := range b.isFunction[y] {
+				if !fn {
+					free++
+				}
+			}
+		}
+		assert.Equal(t, free, getRMQRNumRawDataModules(ver), "version %d raw modules", ver)
+
+		for row, ecl := range []Ecc{Medium, High} {
+			assert.Equal(t, wantData[row][ver], getRMQRNumDataCodewords(ver, ecl), "version %d ecl %v", ver, ecl)
+		}
+	}
+}
+
+func TestEncodeRMQRVersionSelection(t *testing.T) {
+	qr, err := EncodeRMQRText("HELLO", Medium)
+	assert.NoError(t, err)
+	assert.Equal(t, SymbolRMQR, qr.SymbolType())
+	assert.Equal(t, 11, qr.Height())
+	assert.Equal(t, 27, qr.Width())
+	assert.Equal(t, 2, qr.QuietZone())
+
+	// Restricting the range to the 7-row versions keeps the symbol short.
+	minVer, err := RMQRVersion(7, 43)
+	assert.NoError(t, err)
+	maxVer, err := RMQRVersion(7, 139)
+	assert.NoError(t, err)
+	segs, err := MakeSegments("https://example.com/parts/42")
+	assert.NoError(t, err)
+	qr, err = EncodeRMQRSegments(segs, Medium, minVer, maxVer, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, qr.Height())
+	assert.Equal(t, Medium, qr.errorCorrectionLevel)
+
+	// R17x139 at Medium holds 361 digits.
+	qr, err = EncodeRMQRText(strings.Repeat("7", 361), Medium)
+	assert.NoError(t, err)
+	assert.Equal(t, MaxRMQRVersion, qr.version)
+	_, err = EncodeRMQRText(strings.Repeat("7", 362), Medium)
+	assert.True(t, errors.Is(err, ErrDataTooLong), "%v", err)
+}
+
+func TestEncodeRMQRErrors(t *testing.T) {
+	_, err := EncodeRMQRText("HELLO", Low)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+	_, err = EncodeRMQRSegments(nil, Medium, MinRMQRVersion, MaxRMQRVersion, false)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+	_, err = EncodeRMQRSegments([]*QrSegment{}, Medium, 0, 33, false)
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+
+	_, err = RMQRVersion(8, 43)
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+}
+
+// TestRMQRFunctionPatterns checks the finder, sub-finder and that both format
+// information copies decode to the ECC level and version.
+func TestRMQRFunctionPatterns(t *testing.T) {
+	qr, err := EncodeRMQRText("01234567", High)
+	assert.NoError(t, err)
+	w, h := qr.Width(), qr.Height()
+
+	for i := 0; i < 7; i++ {
+		assert.True(t, qr.Module(i, 0) && qr.Module(0, i) && qr.Module(6, i))
+	}
+	assert.True(t, qr.Module(w-3, h-3))
+	assert.False(t, qr.Module(w-2, h-2))
+	assert.True(t, qr.Module(w-1, h-1))
+
+	var left, right int
+	for i := 0; i < 18; i++ {
+		lx, ly := 8+i/5, 1+i%5
+		rx, ry := w-8+i/5, h-6+i%5
+		if i >= 15 {
+			lx, ly = 11, 1+i-15
+			rx, ry = w-5+i-15, h-6
+		}
+		if qr.Module(lx, ly) {
+			left |= 1 << i
+		}
+		if qr.Module(rx, ry) {
+			right |= 1 << i
+		}
+	}
+	data := 1<<5 | (qr.version - 1)
+	assert.Equal(t, data, (left^0x1FAB2)>>12)
+	assert.Equal(t, data, (right^0x20A7B)>>12)
+}
+
+func TestRMQRRender(t *testing.T) {
+	qr, err := EncodeRMQRText("HELLO", Medium)
+	assert.NoError(t, err)
+
+	img, err := qr.ToImage(NewQrCodeImgConfig(2, qr.QuietZone()))
+	assert.NoError(t, err)
+	assert.Equal(t, (27+4)*2, img.Bounds().Dx())
+	assert.Equal(t, (11+4)*2, img.Bounds().Dy())
+
+	svg, err := qr.ToSVGBytes(NewQrCodeImgConfig(2, 0))
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), `viewBox="0 0 54 22"`)
+
+	svg, err = qr.ToSVGBytes(NewQrCodeImgConfig(2, 0, WithOptimalSVG()))
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), `viewBox="0 0 54 22"`)
+}
@@ -0,0 +1,191 @@
+package go_qr
+
+import "fmt"
+
+// VersionClass groups the QR Code versions that share character count field
+// widths. A segment list takes the same number of bits in every version of a
+// class.
+type VersionClass int
+
+const (
+	VersionClassSmall  VersionClass = iota // versions 1-9
+	VersionClassMedium                     // versions 10-26
+	VersionClassLarge                      // versions 27-40
+)
+
+// classOf returns the class of version ver, matching Mode.numCharCountBits.
+func classOf(ver int) VersionClass {
+	return VersionClass((ver + 7) / 17)
+}
+
+// firstVersion returns the smallest version of the class.
+func (c VersionClass) firstVersion() int {
+	return [...]int{1, 10, 27}[c]
+}
+
+// SegmentBuilder assembles a segment list one segment at a time and keeps
+// the running bit count of each version class, so a mixed payload (ECI,
+// prefix, numeric ID, free text) can be checked against the symbol capacity
+// while it is built rather than when it is encoded.
+//
+// An append that would leave the list too long for every version up to the
+// builder's maximum at its ECC level is refused with a *DataTooLongError and
+// the builder is left unchanged.
+type SegmentBuilder struct {
+	ecl        Ecc
+	maxVersion int
+	segs       []*QrSegment
+	bits       [3]int // per VersionClass; -1 when a character count overflows
+}
+
+// NewSegmentBuilder returns an empty builder whose appends must fit some
+// version up to maxVersion at ecl.
+func NewSegmentBuilder(ecl Ecc, maxVersion int) (*SegmentBuilder, error) {
+	if ecl < Low || ecl > High {
+		return nil, fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, ecl)
+	}
+	if !isValidVersion(MinVersion, maxVersion) {
+		return nil, fmt.Errorf("%w: maxVersion=%d", ErrInvalidVersion, maxVersion)
+	}
+	return &SegmentBuilder{ecl: ecl, maxVersion: maxVersion}, nil
+}
+
+// Append adds seg to the end of the list. It returns an ErrDataTooLong error,
+// without adding seg, if the list would then exceed the capacity of the
+// maximum version.
+func (b *SegmentBuilder) Append(seg *QrSegment) error {
+	if seg == nil {
+		return fmt.Errorf("%w: segment is nil", ErrInvalidArgument)
+	}
+	next := b.bits
+	for c := range next {
+		ver := VersionClass(c).firstVersion()
+		if next[c] < 0 || seg.numChars >= 1<<seg.mode.numCharCountBits(ver) {
+			next[c] = -1
+			continue
+		}
+		next[c] += seg.mode.headerBits(ver) + seg.data.len()
+	}
+	if _, ok := smallestVersion(next, b.ecl, b.maxVersion); !ok {
+		return b.tooLong(next, b.ecl)
+	}
+	b.segs = append(b.segs, seg)
+	b.bits = next
+	return nil
+}
+
+// AppendNumeric appends a numeric segment for digits. See MakeNumeric.
+func (b *SegmentBuilder) AppendNumeric(digits string) error {
+	seg, err := MakeNumeric(digits)
+	if err != nil {
+		return err
+	}
+	return b.Append(seg)
+}
+
+// AppendAlphanumeric appends an alphanumeric segment for text. See
+// MakeAlphanumeric.
+func (b *SegmentBuilder) AppendAlphanumeric(text string) error {
+	seg, err := MakeAlphanumeric(text)
+	if err != nil {
+		return err
+	}
+	return b.Append(seg)
+}
+
+// AppendBytes appends a byte segment for data. See MakeBytes.
+func (b *SegmentBuilder) AppendBytes(data []byte) error {
+	seg, err := MakeBytes(data)
+	if err != nil {
+		return err
+	}
+	return b.Append(seg)
+}
+
+// AppendEci appends an ECI designator. See MakeEci.
+func (b *SegmentBuilder) AppendEci(val int) error {
+	seg, err := MakeEci(val)
+	if err != nil {
+		return err
+	}
+	return b.Append(seg)
+}
+
+// Segments returns a copy of the segment list.
+func (b *SegmentBuilder) Segments() []*QrSegment {
+	return append([]*QrSegment{}, b.segs...)
+}
+
+// Bits returns the bits the segments take in versions of class c: mode
+// indicators, character counts and data, before terminator and padding. It
+// returns -1 if a segment has more characters than its count field holds in
+// that class, or if c is not a VersionClass constant.
+func (b *SegmentBuilder) Bits(c VersionClass) int {
+	if c < VersionClassSmall || c > VersionClassLarge {
+		return -1
+	}
+	return b.bits[c]
+}
+
+// BitsAt returns Bits for the class of version ver, or -1 if ver is outside
+// [MinVersion, MaxVersion].
+func (b *SegmentBuilder) BitsAt(ver int) int {
+	if ver < MinVersion || ver > MaxVersion {
+		return -1
+	}
+	return b.bits[classOf(ver)]
+}
+
+// SmallestVersion returns the smallest version, up to the builder's maximum,
+// whose data capacity at ecl holds the segments. It returns an
+// ErrDataTooLong error if none does.
+func (b *SegmentBuilder) SmallestVersion(ecl Ecc) (int, error) {
+	if ecl < Low || ecl > High {
+		return 0, fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, ecl)
+	}
+	ver, ok := smallestVersion(b.bits, ecl, b.maxVersion)
+	if !ok {
+		return 0, b.tooLong(b.bits, ecl)
+	}
+	return ver, nil
+}
+
+// HeadroomBits returns how many more bits fit the maximum version at the
+// builder's ECC level.
+func (b *SegmentBuilder) HeadroomBits() int {
+	used := b.bits[classOf(b.maxVersion)]
+	if used < 0 {
+		return 0
+	}
+	return getNumDataCodewords(b.maxVersion, b.ecl)*8 - used
+}
+
+// Encode encodes the segments in the smallest fitting version at the
+// builder's ECC level, boosting the level when that costs no version, and
+// with the lowest-penalty mask.
+func (b *SegmentBuilder) Encode() (*QrCode, error) {
+	return EncodeSegments(b.Segments(), b.ecl, MinVersion, b.maxVersion, -1, true)
+}
+
+// tooLong returns the DataTooLongError for per-class bit counts bits at the
+// maximum version.
+func (b *SegmentBuilder) tooLong(bits [3]int, ecl Ecc) error {
+	return &DataTooLongError{
+		RequiredBits:  bits[classOf(b.maxVersion)],
+		AvailableBits: getNumDataCodewords(b.maxVersion, ecl) * 8,
+		Version:       b.maxVersion,
+		Ecc:           ecl,
+	}
+}
+
+// smallestVersion returns the first version up to maxVer whose capacity at
+// ecl holds the per-class bit counts bits.
+func smallestVersion(bits [3]int, ecl Ecc, maxVer int) (int, bool) {
+	for ver := MinVersion; ver <= maxVer; ver++ {
+		used := bits[classOf(ver)]
+		if used >= 0 && used <= getNumDataCodewords(ver, ecl)*8 {
+			return ver, true
+		}
+	}
+	return 0, false
+}
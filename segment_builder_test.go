@@ -0,0 +1,106 @@
+package go_qr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentBuilder(t *testing.T) {
+	b, err := NewSegmentBuilder(Medium, MaxVersion)
+	assert.NoError(t, err)
+	assert.NoError(t, b.AppendEci(26))
+	assert.NoError(t, b.AppendAlphanumeric("ID:"))
+	assert.NoError(t, b.AppendNumeric("0123456789"))
+	assert.NoError(t, b.AppendBytes([]byte("free text")))
+
+	segs := b.Segments()
+	assert.Len(t, segs, 4)
+	for c := VersionClassSmall; c <= VersionClassLarge; c++ {
+		assert.Equal(t, getTotalBits(segs, c.firstVersion()), b.Bits(c), "class %d", c)
+	}
+	assert.Equal(t, getTotalBits(segs, 20), b.BitsAt(20))
+	for _, c := range []VersionClass{-1, 3, 5} {
+		assert.Equal(t, -1, b.Bits(c), "class %d", c)
+	}
+	for _, ver := range []int{0, 41, 100} {
+		assert.Equal(t, -1, b.BitsAt(ver), "version %d", ver)
+	}
+
+	// SmallestVersion agrees with the encoder.
+	for _, ecl := range []Ecc{Low, Medium, Quartile, High} {
+		ver, err := b.SmallestVersion(ecl)
+		assert.NoError(t, err)
+		want, _, err := fitVersion(segs, ecl, MinVersion, MaxVersion)
+		assert.NoError(t, err)
+		assert.Equal(t, want, ver, "ecc %d", ecl)
+	}
+
+	qr, err := b.Encode()
+	assert.NoError(t, err)
+	ver, _ := b.SmallestVersion(Medium)
+	assert.Equal(t, ver, qr.version)
+	assert.Equal(t, "ID:0123456789free text", decodeSymbol(t, qr).Text)
+}
+
+func TestSegmentBuilderOverflow(t *testing.T) {
+	b, err := NewSegmentBuilder(Low, 1)
+	assert.NoError(t, err)
+	// Version 1-L holds 19 data codewords, 152 bits.
+	assert.Equal(t, 152, b.HeadroomBits())
+
+	assert.NoError(t, b.AppendNumeric(strings.Repeat("1", 30)))
+	before := b.BitsAt(1)
+	assert.Equal(t, 4+10+100, before)
+	assert.Equal(t, 152-before, b.HeadroomBits())
+
+	// 4+8+8*4 = 44 bits more would exceed 152: refused, builder unchanged.
+	err = b.AppendBytes([]byte("abcd"))
+	assert.ErrorIs(t, err, ErrDataTooLong)
+	assert.Len(t, b.Segments(), 1)
+	assert.Equal(t, before, b.BitsAt(1))
+
+	// A smaller append still fits.
+	assert.NoError(t, b.AppendBytes([]byte("ab")))
+	assert.Equal(t, before+4+8+16, b.BitsAt(1))
+
+	_, err = b.SmallestVersion(High)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestSegmentBuilderCharCountOverflow(t *testing.T) {
+	// 1024 digits overflow the 10-bit numeric count of versions 1-9.
+	b, err := NewSegmentBuilder(Low, MaxVersion)
+	assert.NoError(t, err)
+	assert.NoError(t, b.AppendNumeric(strings.Repeat("7", 1024)))
+	assert.Equal(t, -1, b.Bits(VersionClassSmall))
+	assert.Positive(t, b.Bits(VersionClassMedium))
+	ver, err := b.SmallestVersion(Low)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, ver, 10)
+
+	small, err := NewSegmentBuilder(Low, 9)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, small.AppendNumeric(strings.Repeat("7", 1024)), ErrDataTooLong)
+}
+
+func TestSegmentBuilderErrors(t *testing.T) {
+	_, err := NewSegmentBuilder(Ecc(7), MaxVersion)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewSegmentBuilder(Low, 41)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+
+	b, err := NewSegmentBuilder(Low, MaxVersion)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, b.Append(nil), ErrInvalidArgument)
+	assert.ErrorIs(t, b.AppendNumeric("12a"), ErrUnencodableChar)
+	assert.ErrorIs(t, b.AppendAlphanumeric("lower"), ErrUnencodableChar)
+	_, err = b.SmallestVersion(Ecc(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// An empty builder fits version 1.
+	ver, err := b.SmallestVersion(High)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ver)
+}
@@ -0,0 +1,171 @@
+package go_qr
+
+import "fmt"
+
+// MaxStructuredAppendSymbols is the largest number of symbols a Structured
+// Append sequence can link.
+const MaxStructuredAppendSymbols = 16
+
+// structuredAppend is the Structured Append mode (indicator 0011). Its header
+// has no character count; the segment data is the 4-bit symbol index, the
+// 4-bit total minus one and the 8-bit parity byte.
+var structuredAppend = newMode(0x3, 0, 0, 0)
+
+// MakeStructuredAppend creates the Structured Append header segment for symbol
+// index (0-based) of total. parity is the XOR of every byte of the complete
+// message (see StructuredAppendParity) and must be the same in every symbol of
+// the sequence. The header must be the first segment of its symbol.
+func MakeStructuredAppend(index, total int, parity byte) (*QrSegment, error) {
+	if total < 1 || total > MaxStructuredAppendSymbols {
+		return nil, fmt.Errorf("%w: Structured Append total %d out of range [1,%d]", ErrInvalidArgument, total, MaxStructuredAppendSymbols)
+	}
+	if index < 0 || index >= total {
+		return nil, fmt.Errorf("%w: Structured Append index %d out of range [0,%d)", ErrInvalidArgument, index, total)
+	}
+
+	bb := &BitBuffer{}
+	if err := bb.appendBits(index, 4); err != nil {
+		return nil, err
+	}
+	if err := bb.appendBits(total-1, 4); err != nil {
+		return nil, err
+	}
+	if err := bb.appendBits(int(parity), 8); err != nil {
+		return nil, err
+	}
+	return newQrSegment(structuredAppend, 0, bb)
+}
+
+// StructuredAppendParity returns the parity byte of a Structured Append
+// sequence carrying data: the XOR of all of its bytes.
+func StructuredAppendParity(data []byte) byte {
+	var parity byte
+	for _, b := range data {
+		parity ^= b
+	}
+	return parity
+}
+
+// EncodeStructuredAppend encodes text as a Structured Append sequence of at
+// most MaxStructuredAppendSymbols QR Codes, for payloads too long for a single
+// version-40 symbol. It uses the fewest symbols that fit, then the lowest
+// maximum version that still needs no more symbols, so the sequence is as
+// small and even as possible. Each chunk is segmented optimally for that
+// version; chunks are split on character boundaries.
+//
+// Text that fits in one symbol, empty text included, yields a single symbol
+// with a 1-of-1 header.
+// It returns ErrDataTooLong if text needs more than 16 symbols.
+func EncodeStructuredAppend(text string, ecl Ecc) ([]*QrCode, error) {
+	codePoints, err := toCodePoints(text)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, maxVer, err := planStructuredAppend(codePoints, ecl)
+	if err != nil {
+		return nil, err
+	}
+
+	parity := StructuredAppendParity([]byte(text))
+	res := make([]*QrCode, len(chunks))
+	for i, chunk := range chunks {
+		header, err := MakeStructuredAppend(i, len(chunks), parity)
+		if err != nil {
+			return nil, err
+		}
+		// The segmentation structuredAppendChunkFits checked against maxVer,
+		// header included, so the symbol never grows past maxVer.
+		segs, err := makeSegmentsOptimallyWithVersion(chunk, maxVer, false)
+		if err != nil {
+			return nil, err
+		}
+		qr, err := EncodeSegments(append([]*QrSegment{header}, segs...), ecl, MinVersion, maxVer, -1, true)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = qr
+	}
+	return res, nil
+}
+
+// planStructuredAppend cuts codePoints into the fewest chunks that fit, then
+// finds the lowest maximum version that needs no more chunks, and returns the
+// chunks cut for that version.
+func planStructuredAppend(codePoints []int, ecl Ecc) ([][]int, int, error) {
+	// Fewest symbols first: greedy chunking at version 40 is optimal.
+	chunks, err := splitStructuredAppend(codePoints, ecl, MaxVersion)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(chunks) > MaxStructuredAppendSymbols {
+		return nil, 0, fmt.Errorf("%w: text needs more than %d symbols", ErrDataTooLong, MaxStructuredAppendSymbols)
+	}
+
+	// Then the smallest maximum version that keeps the same symbol count.
+	lo, hi := MinVersion, MaxVersion
+	for lo < hi {
+		mid := (lo + hi) / 2
+		c, err := splitStructuredAppend(codePoints, ecl, mid)
+		if err == nil && len(c) <= len(chunks) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo < MaxVersion {
+		if chunks, err = splitStructuredAppend(codePoints, ecl, lo); err != nil {
+			return nil, 0, err
+		}
+	}
+	return chunks, lo, nil
+}
+
+// splitStructuredAppend greedily cuts codePoints into the longest chunks that,
+// behind a Structured Append header, fit in maxVer at ecl. It stops early once
+// the chunk count exceeds MaxStructuredAppendSymbols.
+func splitStructuredAppend(codePoints []int, ecl Ecc, maxVer int) ([][]int, error) {
+	var chunks [][]int
+	for len(codePoints) > 0 || chunks == nil {
+		if len(chunks) > MaxStructuredAppendSymbols {
+			break
+		}
+		// Binary search for the longest fitting prefix; fitting is monotone in
+		// the prefix length. No character costs less than 10/3 bits (numeric),
+		// which bounds the search.
+		lo, hi := 0, min(len(codePoints), getNumDataCodewords(maxVer, ecl)*8*3/10+1)
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			ok, err := structuredAppendChunkFits(codePoints[:mid], ecl, maxVer)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		if lo == 0 && len(codePoints) > 0 {
+			return nil, fmt.Errorf("%w: character does not fit a version-%d symbol", ErrDataTooLong, maxVer)
+		}
+		chunks = append(chunks, codePoints[:lo])
+		codePoints = codePoints[lo:]
+	}
+	return chunks, nil
+}
+
+// structuredAppendChunkFits reports whether chunk, optimally segmented for
+// maxVer and preceded by a Structured Append header, fits in maxVer at ecl.
+func structuredAppendChunkFits(chunk []int, ecl Ecc, maxVer int) (bool, error) {
+	segs, err := makeSegmentsOptimallyWithVersion(chunk, maxVer, false)
+	if err != nil {
+		return false, err
+	}
+	header, err := MakeStructuredAppend(0, 1, 0)
+	if err != nil {
+		return false, err
+	}
+	usedBits := getTotalBits(append([]*QrSegment{header}, segs...), maxVer)
+	return usedBits != -1 && usedBits <= getNumDataCodewords(maxVer, ecl)*8, nil
+}
@@ -0,0 +1,94 @@
+package go_qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeStructuredAppend(t *testing.T) {
+	seg, err := MakeStructuredAppend(2, 5, 0xA7)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, seg.data.len())
+	assert.Equal(t, []byte{0x24, 0xA7}, seg.data.data)
+
+	_, err = MakeStructuredAppend(0, 17, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = MakeStructuredAppend(3, 3, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+// TestEncodeStructuredAppendRoundTrip splits a payload larger than a
+// version-40 symbol and decodes every symbol back.
+func TestEncodeStructuredAppendRoundTrip(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; sb.Len() < 3000; i++ {
+		sb.WriteString("line ")
+		sb.WriteString(strings.Repeat("0123456789", i%4))
+		sb.WriteString(" Grüße;")
+	}
+	text := sb.String()
+
+	_, err := EncodeText(text, Medium)
+	assert.True(t, errors.Is(err, ErrDataTooLong))
+
+	qrs, err := EncodeStructuredAppend(text, Medium)
+	assert.NoError(t, err)
+	assert.Len(t, qrs, 2)
+
+	var joined strings.Builder
+	for i, qr := range qrs {
+		img, err := qr.ToImage(NewQrCodeImgConfig(2, 4))
+		assert.NoError(t, err)
+		res, err := DecodeDetailed(img, WithFastPathOnly())
+		assert.NoError(t, err)
+		assert.Equal(t, &StructuredAppendInfo{Index: i, Total: 2, Parity: StructuredAppendParity([]byte(text))}, res.StructuredAppend)
+		joined.WriteString(res.Text)
+	}
+	assert.Equal(t, text, joined.String())
+}
+
+func TestEncodeStructuredAppendLimits(t *testing.T) {
+	qrs, err := EncodeStructuredAppend("HELLO", Low)
+	assert.NoError(t, err)
+	assert.Len(t, qrs, 1)
+	assert.Equal(t, 1, qrs[0].version)
+
+	_, err = EncodeStructuredAppend(strings.Repeat("x", 16*2953), Low)
+	assert.True(t, errors.Is(err, ErrDataTooLong), "%v", err)
+
+	// Empty text is a 1-of-1 symbol with an empty body.
+	qrs, err = EncodeStructuredAppend("", Low)
+	assert.NoError(t, err)
+	if assert.Len(t, qrs, 1) {
+		res := decodeSymbol(t, qrs[0])
+		assert.Equal(t, "", res.Text)
+		assert.Equal(t, &StructuredAppendInfo{Index: 0, Total: 1}, res.StructuredAppend)
+	}
+}
+
+func TestEncodeStructuredAppendVersionBound(t *testing.T) {
+	// No symbol grows past the maximum version the chunks were cut for, the
+	// Structured Append header included.
+	for _, text := range []string{
+		"",
+		strings.Repeat("ABC123 ", 700),
+		strings.Repeat("0123456789", 800) + strings.Repeat("é", 500),
+		strings.Repeat("Grüße, 42;", 400),
+	} {
+		codePoints, err := toCodePoints(text)
+		assert.NoError(t, err)
+		chunks, maxVer, err := planStructuredAppend(codePoints, Quartile)
+		assert.NoError(t, err)
+		qrs, err := EncodeStructuredAppend(text, Quartile)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Len(t, qrs, len(chunks))
+		for _, qr := range qrs {
+			assert.LessOrEqual(t, qr.version, maxVer)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package go_qr
+
+import "fmt"
+
+// SymbolBuilder assembles a QR Code from raw data codewords, with hooks to
+// deviate from the standard: codewords can be overwritten after Reed-Solomon
+// encoding and interleaving, the format information replaced and individual
+// modules forced. It is meant for scanner test vectors, such as symbols with
+// a chosen mask, corrupted blocks or inconsistent format bits; Encode and
+// EncodeSegments are the way to make ordinary symbols.
+//
+// The overrides are recorded, not applied, until Build, which can be called
+// any number of times.
+type SymbolBuilder struct {
+	version   int
+	ecl       Ecc
+	mask      int
+	codewords []byte // data and ECC codewords in placement order
+
+	formatBits int // -1 to derive the format information from ecl and mask
+	modules    map[[2]int]bool
+}
+
+// NewSymbolBuilder returns a builder for a version-version symbol at ecl whose
+// data codewords are data, terminator and padding included: exactly as many
+// bytes as the version holds at that level. mask is 0-7, or -1 to pick the
+// lowest-penalty mask at Build.
+func NewSymbolBuilder(version int, ecl Ecc, data []byte, mask int) (*SymbolBuilder, error) {
+	if !isValidVersion(version, version) {
+		return nil, fmt.Errorf("%w: version=%d", ErrInvalidVersion, version)
+	}
+	if ecl < Low || ecl > High {
+		return nil, fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, ecl)
+	}
+	if mask < -1 || mask > 7 {
+		return nil, fmt.Errorf("%w: mask value out of range", ErrInvalidArgument)
+	}
+	if want := getNumDataCodewords(version, ecl); len(data) != want {
+		return nil, fmt.Errorf("%w: version %d at ecc level %d takes %d data codewords, got %d", ErrInvalidArgument, version, ecl, want, len(data))
+	}
+	codewords, err := newBuilder(version, ecl).addEccAndInterLeave(data)
+	if err != nil {
+		return nil, err
+	}
+	return &SymbolBuilder{
+		version:    version,
+		ecl:        ecl,
+		mask:       mask,
+		codewords:  codewords,
+		formatBits: -1,
+		modules:    map[[2]int]bool{},
+	}, nil
+}
+
+// MakeDataCodewords returns the data codewords of segs in a version-version
+// symbol at ecl: segment bits, terminator, bit padding and pad bytes, ready
+// for NewSymbolBuilder.
+func MakeDataCodewords(segs []*QrSegment, version int, ecl Ecc) ([]byte, error) {
+	if segs == nil {
+		return nil, fmt.Errorf("%w: segments slice is nil", ErrInvalidArgument)
+	}
+	if !isValidVersion(version, version) {
+		return nil, fmt.Errorf("%w: version=%d", ErrInvalidVersion, version)
+	}
+	if ecl < Low || ecl > High {
+		return nil, fmt.Errorf("%w: ecc level %d out of range", ErrInvalidArgument, ecl)
+	}
+	if _, _, err := fitVersion(segs, ecl, version, version); err != nil {
+		return nil, err
+	}
+	data, _, err := makeDataCodewords(segs, version, ecl)
+	return data, err
+}
+
+// Codewords returns a copy of the data and ECC codewords in the order they are
+// placed in the symbol, interleaved across blocks, with any overrides.
+// ModuleLayout maps codeword indices to modules.
+func (b *SymbolBuilder) Codewords() []byte {
+	return append([]byte{}, b.codewords...)
+}
+
+// SetCodeword replaces codeword i of the Codewords sequence. The ECC is not
+// recomputed, so a changed data or ECC codeword is an error a reader must
+// correct.
+func (b *SymbolBuilder) SetCodeword(i int, val byte) error {
+	if i < 0 || i >= len(b.codewords) {
+		return fmt.Errorf("%w: codeword index %d out of range [0,%d)", ErrInvalidArgument, i, len(b.codewords))
+	}
+	b.codewords[i] = val
+	return nil
+}
+
+// SetFormatBits draws bits, a 15-bit format information word as it appears in
+// the symbol (BCH-encoded and XOR-masked with 0x5412), in both format areas
+// instead of the word for the builder's ECC level and mask.
+// qrspec.FormatInfoBits gives the standard words.
+func (b *SymbolBuilder) SetFormatBits(bits int) error {
+	if bits < 0 || bits >= 1<<15 {
+		return fmt.Errorf("%w: format bits %#x exceed 15 bits", ErrInvalidArgument, bits)
+	}
+	b.formatBits = bits
+	return nil
+}
+
+// SetModule forces the module at (x, y) dark or light. Module overrides are
+// applied last, after masking and format information, and may hit any module,
+// function patterns included.
+func (b *SymbolBuilder) SetModule(x, y int, dark bool) error {
+	size := b.version*4 + 17
+	if x < 0 || x >= size || y < 0 || y >= size {
+		return fmt.Errorf("%w: module (%d,%d) outside the %dx%d symbol", ErrInvalidArgument, x, y, size, size)
+	}
+	b.modules[[2]int{x, y}] = dark
+	return nil
+}
+
+// Build draws the symbol and returns it as an immutable QrCode. Its Mask is
+// the mask applied to the data, whatever SetFormatBits announced.
+func (b *SymbolBuilder) Build() (*QrCode, error) {
+	q := newBuilder(b.version, b.ecl)
+	q.drawFunctionPatterns()
+	if err := q.drawCodewords(b.codewords); err != nil {
+		return nil, err
+	}
+
+	var sel MaskSelector
+	if b.mask != -1 {
+		sel = fixedMask(b.mask)
+	}
+	msk, err := q.selectMask(sel)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.applyMask(msk); err != nil {
+		return nil, err
+	}
+	if b.formatBits >= 0 {
+		q.drawFormatInfo(b.formatBits)
+	} else {
+		q.drawFormatBits(msk)
+	}
+
+	for pos, dark := range b.modules {
+		q.modules[pos[1]][pos[0]] = dark
+	}
+	return q.toQrCode(msk), nil
+}
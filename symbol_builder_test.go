@@ -0,0 +1,96 @@
+package go_qr
+
+import (
+	"testing"
+
+	"github.com/piglig/go-qr/qrspec"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSymbolBuilder(t *testing.T, text string, version int, ecl Ecc, mask int) *SymbolBuilder {
+	data, err := MakeDataCodewords(mustSegments(t, text), version, ecl)
+	assert.NoError(t, err)
+	b, err := NewSymbolBuilder(version, ecl, data, mask)
+	assert.NoError(t, err)
+	return b
+}
+
+func TestSymbolBuilderMatchesEncoder(t *testing.T) {
+	text := "https://example.com/scanner-test"
+	for _, mask := range []int{-1, 0, 5} {
+		want, err := EncodeSegments(mustSegments(t, text), Medium, 3, 3, mask, false)
+		assert.NoError(t, err)
+		got, err := newTestSymbolBuilder(t, text, 3, Medium, mask).Build()
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, "mask %d", mask)
+	}
+}
+
+func TestSymbolBuilderCorruptCodewords(t *testing.T) {
+	text := "HELLO SCANNER"
+	b := newTestSymbolBuilder(t, text, 2, Medium, 3)
+	// 2-M is one block of 28 data and 16 ECC codewords: up to 8 errors are
+	// correctable.
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, b.SetCodeword(i*5, b.Codewords()[i*5]^0xFF))
+	}
+	qr, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, text, decodeSymbol(t, qr).Text)
+
+	assert.NoError(t, b.SetCodeword(41, b.Codewords()[41]^0xFF))
+	qr, err = b.Build()
+	assert.NoError(t, err)
+	img, err := qr.ToImage(NewQrCodeImgConfig(4, 4))
+	assert.NoError(t, err)
+	_, err = Decode(img)
+	assert.ErrorIs(t, err, ErrDecodeFailed)
+
+	assert.ErrorIs(t, b.SetCodeword(44, 0), ErrInvalidArgument)
+	assert.ErrorIs(t, b.SetCodeword(-1, 0), ErrInvalidArgument)
+}
+
+func TestSymbolBuilderFormatBitsAndModules(t *testing.T) {
+	b := newTestSymbolBuilder(t, "FORMAT", 1, Low, 2)
+	// Announce mask 6 while the data carries mask 2.
+	assert.NoError(t, b.SetFormatBits(qrspec.FormatInfoBits(qrspec.Level(Low), 6)))
+	qr, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, qr.Mask())
+	bits := qrspec.FormatInfoBits(qrspec.Level(Low), 6)
+	for i := 0; i <= 5; i++ {
+		assert.Equal(t, bits>>i&1 == 1, qr.Module(8, i))
+	}
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, bits>>i&1 == 1, qr.Module(qr.Size()-1-i, 8))
+	}
+
+	// Module overrides win over everything, finder patterns included.
+	assert.True(t, qr.Module(0, 0))
+	assert.NoError(t, b.SetModule(0, 0, false))
+	assert.NoError(t, b.SetModule(10, 12, !qr.Module(10, 12)))
+	qr2, err := b.Build()
+	assert.NoError(t, err)
+	assert.False(t, qr2.Module(0, 0))
+	assert.NotEqual(t, qr.Module(10, 12), qr2.Module(10, 12))
+
+	assert.ErrorIs(t, b.SetFormatBits(1<<15), ErrInvalidArgument)
+	assert.ErrorIs(t, b.SetModule(21, 0, true), ErrInvalidArgument)
+}
+
+func TestSymbolBuilderErrors(t *testing.T) {
+	data := make([]byte, 19)
+	_, err := NewSymbolBuilder(0, Low, data, 0)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+	_, err = NewSymbolBuilder(1, Ecc(4), data, 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewSymbolBuilder(1, Low, data, 8)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewSymbolBuilder(1, Low, data[:18], 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = MakeDataCodewords(nil, 1, Low)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = MakeDataCodewords(mustSegments(t, "0123456789012345678901234567890123456789012"), 1, High)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}